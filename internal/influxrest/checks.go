@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"net/url"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Check holds the fields shared by threshold, deadman and custom checks.
+type Check struct {
+	Id          string `json:"id"`
+	OrgID       string `json:"orgID"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Every       string `json:"every"`
+	Description string `json:"description"`
+	Query       struct {
+		Text string `json:"text"`
+	} `json:"query"`
+	Labels domain.Labels `json:"labels"`
+}
+
+// CustomCheck is the body of create and replace requests of a custom check.
+type CustomCheck struct {
+	Type   string `json:"type"`
+	OrgID  string `json:"orgID"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Query  struct {
+		Text string `json:"text"`
+	} `json:"query"`
+}
+
+// GetCheck reads the check with id.
+func GetCheck(ctx context.Context, client influxdb2.Client, id string) (Check, error) {
+	return get[Check](ctx, client, "checks/"+url.PathEscape(id))
+}
+
+// ListChecks returns every check of the organization with orgID.
+func ListChecks(ctx context.Context, client influxdb2.Client, orgID string) ([]Check, error) {
+	return List[Check](ctx, client, "checks", url.Values{"orgID": {orgID}}, "checks")
+}
+
+// SaveCustomCheck creates check, or replaces the check with id when id is not
+// empty, and returns the check as stored.
+func SaveCustomCheck(ctx context.Context, client influxdb2.Client, id string, check CustomCheck) (Check, error) {
+	return save[Check](ctx, client, "checks", id, check)
+}
+
+// DeleteCheck deletes the check with id.
+func DeleteCheck(ctx context.Context, client influxdb2.Client, id string) error {
+	return remove(ctx, client, "checks/"+url.PathEscape(id))
+}
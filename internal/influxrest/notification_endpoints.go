@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// NotificationEndpoint holds the non secret fields of a notification
+// endpoint, whatever its type.
+type NotificationEndpoint struct {
+	Id          string `json:"id"`
+	OrgID       string `json:"orgID"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+}
+
+// TelegramEndpoint is the request and response body of telegram notification
+// endpoints. The server stores the token as a secret and never returns its
+// value.
+type TelegramEndpoint struct {
+	Id      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	OrgID   string `json:"orgID"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Token   string `json:"token,omitempty"`
+	Channel string `json:"channel"`
+
+	Labels domain.Labels `json:"labels,omitempty"`
+}
+
+// GetNotificationEndpoint reads the endpoint with id.
+func GetNotificationEndpoint(ctx context.Context, client influxdb2.Client, id string) (NotificationEndpoint, error) {
+	return get[NotificationEndpoint](ctx, client, "notificationEndpoints/"+url.PathEscape(id))
+}
+
+// ListNotificationEndpoints returns every endpoint of the organization with
+// orgID.
+func ListNotificationEndpoints(ctx context.Context, client influxdb2.Client, orgID string) ([]NotificationEndpoint, error) {
+	return List[NotificationEndpoint](ctx, client, "notificationEndpoints", url.Values{"orgID": {orgID}}, "notificationEndpoints")
+}
+
+// ListTelegramEndpoints returns the telegram endpoints of the organization
+// with orgID.
+func ListTelegramEndpoints(ctx context.Context, client influxdb2.Client, orgID string) ([]TelegramEndpoint, error) {
+	endpoints, err := List[TelegramEndpoint](ctx, client, "notificationEndpoints", url.Values{"orgID": {orgID}}, "notificationEndpoints")
+
+	if err != nil {
+		return nil, err
+	}
+
+	telegram := make([]TelegramEndpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		if endpoint.Type == "telegram" {
+			telegram = append(telegram, endpoint)
+		}
+	}
+
+	return telegram, nil
+}
+
+// GetTelegramEndpoint reads the endpoint with id and fails when it is not a
+// telegram endpoint.
+func GetTelegramEndpoint(ctx context.Context, client influxdb2.Client, id string) (TelegramEndpoint, error) {
+	endpoint, err := get[TelegramEndpoint](ctx, client, "notificationEndpoints/"+url.PathEscape(id))
+
+	if err == nil && endpoint.Type != "telegram" {
+		err = fmt.Errorf("notification endpoint %s is a %s endpoint, not a telegram endpoint", id, endpoint.Type)
+	}
+
+	return endpoint, err
+}
+
+// SaveTelegramEndpoint creates endpoint, or replaces the endpoint with id when
+// id is not empty, and returns the endpoint as stored.
+func SaveTelegramEndpoint(ctx context.Context, client influxdb2.Client, id string, endpoint TelegramEndpoint) (TelegramEndpoint, error) {
+	return save[TelegramEndpoint](ctx, client, "notificationEndpoints", id, endpoint)
+}
+
+// DeleteNotificationEndpoint deletes the endpoint with id.
+func DeleteNotificationEndpoint(ctx context.Context, client influxdb2.Client, id string) error {
+	return remove(ctx, client, "notificationEndpoints/"+url.PathEscape(id))
+}
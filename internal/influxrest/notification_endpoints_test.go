@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+func TestGetTelegramEndpointRejectsOtherTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "name": "hook", "type": "http", "status": "active"}`))
+	}))
+	defer server.Close()
+
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	if _, err := GetTelegramEndpoint(context.Background(), client, "0000000000000001"); err == nil {
+		t.Error("expected an error for an http endpoint")
+	}
+}
+
+func TestListTelegramEndpointsSkipsOtherTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/notificationEndpoints" || r.URL.Query().Get("orgID") != "0000000000000002" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"notificationEndpoints": [
+			{"id": "0000000000000001", "name": "hook", "type": "http"},
+			{"id": "0000000000000003", "name": "pager", "type": "telegram", "channel": "-1001234"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	endpoints, err := ListTelegramEndpoints(context.Background(), client, "0000000000000002")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(endpoints) != 1 || endpoints[0].Name != "pager" || endpoints[0].Channel != "-1001234" {
+		t.Errorf("expected only the telegram endpoint, got %+v", endpoints)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"net/url"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// NotificationRule holds the fields shared by notification rules of every
+// endpoint type.
+type NotificationRule struct {
+	Id          string `json:"id"`
+	OrgID       string `json:"orgID"`
+	Name        string `json:"name"`
+	EndpointID  string `json:"endpointID"`
+	Type        string `json:"type"`
+	Every       string `json:"every"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	StatusRules []struct {
+		CurrentLevel  string `json:"currentLevel"`
+		PreviousLevel string `json:"previousLevel"`
+	} `json:"statusRules"`
+	TagRules []struct {
+		Key      string `json:"key"`
+		Value    string `json:"value"`
+		Operator string `json:"operator"`
+	} `json:"tagRules"`
+}
+
+// GetNotificationRule reads the rule with id.
+func GetNotificationRule(ctx context.Context, client influxdb2.Client, id string) (NotificationRule, error) {
+	return get[NotificationRule](ctx, client, "notificationRules/"+url.PathEscape(id))
+}
+
+// ListNotificationRules returns every rule of the organization with orgID.
+func ListNotificationRules(ctx context.Context, client influxdb2.Client, orgID string) ([]NotificationRule, error) {
+	return List[NotificationRule](ctx, client, "notificationRules", url.Values{"orgID": {orgID}}, "notificationRules")
+}
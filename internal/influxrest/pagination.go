@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// PageSize is the number of items requested per page when walking list
+// endpoints. It is a variable so tests can exercise multi page responses.
+var PageSize = 100
+
+// FetchAllPages walks a limit/offset paginated endpoint until it returns a
+// page shorter than the requested limit.
+func FetchAllPages[T any](ctx context.Context, fetch func(ctx context.Context, offset int, limit int) ([]T, error)) ([]T, error) {
+	var items []T
+
+	for offset := 0; ; offset += PageSize {
+		page, err := fetch(ctx, offset, PageSize)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if len(page) < PageSize {
+			return items, nil
+		}
+	}
+}
+
+// FetchAllPagesAfter walks an endpoint paginated with an `after` cursor, using
+// the id of the last item of each page as the cursor for the next one.
+func FetchAllPagesAfter[T any](ctx context.Context, fetch func(ctx context.Context, after string, limit int) ([]T, error), id func(T) string) ([]T, error) {
+	var items []T
+
+	after := ""
+
+	for {
+		page, err := fetch(ctx, after, PageSize)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if len(page) < PageSize {
+			return items, nil
+		}
+
+		after = id(page[len(page)-1])
+	}
+}
+
+// ListOrganizations returns every organization matching params.
+func ListOrganizations(ctx context.Context, client influxdb2.Client, params domain.GetOrgsParams) ([]domain.Organization, error) {
+	return FetchAllPages(ctx, func(ctx context.Context, offset int, limit int) ([]domain.Organization, error) {
+		pageParams := params
+		pageOffset := domain.Offset(offset)
+		pageLimit := domain.Limit(limit)
+		pageParams.Offset = &pageOffset
+		pageParams.Limit = &pageLimit
+
+		response, err := client.APIClient().GetOrgs(ctx, &pageParams)
+
+		if err != nil || response.Orgs == nil {
+			return nil, err
+		}
+
+		return *response.Orgs, nil
+	})
+}
+
+// ListBuckets returns every bucket matching params.
+func ListBuckets(ctx context.Context, client influxdb2.Client, params domain.GetBucketsParams) ([]domain.Bucket, error) {
+	return FetchAllPages(ctx, func(ctx context.Context, offset int, limit int) ([]domain.Bucket, error) {
+		pageParams := params
+		pageOffset := domain.Offset(offset)
+		pageLimit := domain.Limit(limit)
+		pageParams.Offset = &pageOffset
+		pageParams.Limit = &pageLimit
+
+		response, err := client.APIClient().GetBuckets(ctx, &pageParams)
+
+		if err != nil || response.Buckets == nil {
+			return nil, err
+		}
+
+		return *response.Buckets, nil
+	})
+}
+
+// ListTasks returns every task matching params.
+func ListTasks(ctx context.Context, client influxdb2.Client, params domain.GetTasksParams) ([]domain.Task, error) {
+	return FetchAllPagesAfter(ctx, func(ctx context.Context, after string, limit int) ([]domain.Task, error) {
+		pageParams := params
+		pageParams.Limit = &limit
+
+		if after != "" {
+			pageParams.After = &after
+		}
+
+		response, err := client.APIClient().GetTasks(ctx, &pageParams)
+
+		if err != nil || response.Tasks == nil {
+			return nil, err
+		}
+
+		return *response.Tasks, nil
+	}, func(task domain.Task) string { return task.Id })
+}
+
+// List returns every item of a limit/offset paginated /api/v2 list
+// endpoint whose response wraps the items in the key field, for endpoints
+// the generated client cannot decode.
+func List[T any](ctx context.Context, client influxdb2.Client, path string, query url.Values, key string) ([]T, error) {
+	return FetchAllPages(ctx, func(ctx context.Context, offset int, limit int) ([]T, error) {
+		pageQuery := url.Values{}
+
+		for name, values := range query {
+			pageQuery[name] = values
+		}
+
+		pageQuery.Set("offset", strconv.Itoa(offset))
+		pageQuery.Set("limit", strconv.Itoa(limit))
+
+		payload, err := Do(ctx, client, Request{
+			Method: http.MethodGet,
+			Path:   path,
+			Query:  pageQuery,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		var response map[string]json.RawMessage
+
+		if err := json.Unmarshal(payload, &response); err != nil {
+			return nil, err
+		}
+
+		var items []T
+
+		if raw, ok := response[key]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+	})
+}
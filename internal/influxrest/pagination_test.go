@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// setPageSize overrides PageSize for the duration of a test.
+func setPageSize(t *testing.T, size int) {
+	previous := PageSize
+	PageSize = size
+
+	t.Cleanup(func() {
+		PageSize = previous
+	})
+}
+
+// newPagedServer serves total items named item-N from path, honouring the
+// limit and offset query parameters, and counts the requests it receives.
+func newPagedServer(t *testing.T, path string, key string, total int, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		*requests++
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		items := []map[string]string{}
+
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]string{
+				"id":   fmt.Sprintf("%016d", i),
+				"name": fmt.Sprintf("item-%d", i),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{key: items})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestListOrganizationsWalksAllPages(t *testing.T) {
+	setPageSize(t, 2)
+
+	requests := 0
+	server := newPagedServer(t, "/api/v2/orgs", "orgs", 5, &requests)
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	organizations, err := ListOrganizations(context.Background(), client, domain.GetOrgsParams{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(organizations) != 5 || organizations[4].Name != "item-4" {
+		t.Errorf("expected 5 organizations, got %+v", organizations)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 page requests, got %d", requests)
+	}
+}
+
+func TestListBucketsWalksAllPages(t *testing.T) {
+	setPageSize(t, 2)
+
+	requests := 0
+	server := newPagedServer(t, "/api/v2/buckets", "buckets", 6, &requests)
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	buckets, err := ListBuckets(context.Background(), client, domain.GetBucketsParams{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(buckets) != 6 {
+		t.Errorf("expected 6 buckets, got %d", len(buckets))
+	}
+
+	// An exactly full last page needs one extra request to detect the end.
+	if requests != 4 {
+		t.Errorf("expected 4 page requests, got %d", requests)
+	}
+}
+
+func TestFetchAllPagesAfter(t *testing.T) {
+	setPageSize(t, 2)
+
+	items := []string{"a", "b", "c", "d", "e"}
+	var cursors []string
+
+	result, err := FetchAllPagesAfter(context.Background(), func(ctx context.Context, after string, limit int) ([]string, error) {
+		cursors = append(cursors, after)
+
+		start := 0
+
+		for i, item := range items {
+			if item == after {
+				start = i + 1
+			}
+		}
+
+		end := start + limit
+
+		if end > len(items) {
+			end = len(items)
+		}
+
+		return items[start:end], nil
+	}, func(item string) string {
+		return item
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result) != 5 || fmt.Sprint(cursors) != "[ b d]" {
+		t.Errorf("unexpected pagination: result=%v cursors=%v", result, cursors)
+	}
+}
+
+func TestListWalksAllPages(t *testing.T) {
+	setPageSize(t, 2)
+
+	requests := 0
+	server := newPagedServer(t, "/api/v2/checks", "checks", 3, &requests)
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	items, err := List[item](context.Background(), client, "checks", nil, "checks")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(items) != 3 || items[2].Name != "item-2" {
+		t.Errorf("expected 3 items, got %+v", items)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected 2 page requests, got %d", requests)
+	}
+}
+
+func TestListOrganizationsEncodesNames(t *testing.T) {
+	names := []string{"métricas/prod", "with space", "100% done", "a+b&c=d", "指标"}
+
+	for _, name := range names {
+		var received string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.URL.Query().Get("org")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"orgs": []map[string]string{{"id": "0000000000000001", "name": received}}})
+		}))
+
+		client := influxdb2.NewClient(server.URL, "token")
+
+		organizations, err := ListOrganizations(context.Background(), client, domain.GetOrgsParams{Org: &name})
+
+		client.Close()
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", name, err)
+		}
+
+		if received != name || len(organizations) != 1 || organizations[0].Name != name {
+			t.Errorf("expected %q to round trip, server received %q", name, received)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package influxrest calls the InfluxDB /api/v2 endpoints that the generated
+// client of influxdb-client-go does not expose in a usable form. Requests go
+// through the authenticated transport of an influxdb2.Client, so they share
+// its host, token and TLS settings.
+package influxrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// Request describes a call to an /api/v2 endpoint.
+type Request struct {
+	Method string
+	// Path is relative to /api/v2/, for example "templates/export".
+	Path   string
+	Query  url.Values
+	Accept string
+	// Body is sent as JSON when not nil.
+	Body any
+}
+
+// Do sends req through the authenticated transport of client and returns the
+// response body of a successful call. Other responses are returned as an
+// *Error.
+func Do(ctx context.Context, client influxdb2.Client, req Request) ([]byte, error) {
+	api := client.APIClient()
+
+	endpoint, err := url.Parse(api.APIEndpoint + req.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Query) > 0 {
+		endpoint.RawQuery = req.Query.Encode()
+	}
+
+	var body io.Reader
+
+	if req.Body != nil {
+		payload, err := json.Marshal(req.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, endpoint.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if req.Accept != "" {
+		httpReq.Header.Set("Accept", req.Accept)
+	}
+
+	rsp, err := api.Client.Do(httpReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rsp.Body.Close() }()
+
+	payload, err := io.ReadAll(rsp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, DecodeError(rsp, payload)
+	}
+
+	return payload, nil
+}
+
+// get decodes the JSON response of a GET request to path into a T.
+func get[T any](ctx context.Context, client influxdb2.Client, path string) (T, error) {
+	var result T
+
+	payload, err := Do(ctx, client, Request{Method: http.MethodGet, Path: path})
+
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(payload, &result)
+
+	return result, err
+}
+
+// save creates an object by posting body to path, or replaces the object with
+// id by putting body to path/id when id is not empty, and decodes the stored
+// object into a T.
+func save[T any](ctx context.Context, client influxdb2.Client, path string, id string, body any) (T, error) {
+	var result T
+
+	req := Request{Method: http.MethodPost, Path: path, Body: body}
+
+	if id != "" {
+		req = Request{Method: http.MethodPut, Path: path + "/" + url.PathEscape(id), Body: body}
+	}
+
+	payload, err := Do(ctx, client, req)
+
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(payload, &result)
+
+	return result, err
+}
+
+// remove deletes the object at path.
+func remove(ctx context.Context, client influxdb2.Client, path string) error {
+	_, err := Do(ctx, client, Request{Method: http.MethodDelete, Path: path})
+
+	return err
+}
+
+// Error is returned by Do for non 2xx responses.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+
+	return e.Message
+}
+
+// DecodeError builds an error from an InfluxDB error response, which is
+// usually a JSON document with code and message fields.
+func DecodeError(rsp *http.Response, payload []byte) error {
+	apiError := &Error{StatusCode: rsp.StatusCode}
+
+	if strings.Contains(rsp.Header.Get("Content-Type"), "json") && json.Unmarshal(payload, apiError) == nil && apiError.Message != "" {
+		return apiError
+	}
+
+	apiError.Code = ""
+	apiError.Message = rsp.Status
+
+	if len(payload) > 0 {
+		apiError.Message = fmt.Sprintf("%s: %s", rsp.Status, payload)
+	}
+
+	return apiError
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package influxrest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+func TestDoEncodesPathAndQuery(t *testing.T) {
+	var escapedPath, stream string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		escapedPath = r.URL.EscapedPath()
+		stream = r.URL.Query().Get("stream")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	name := "métricas/prod 100% 指标"
+
+	_, err := Do(context.Background(), client, Request{
+		Method: http.MethodGet,
+		Path:   "items/" + url.PathEscape(name),
+		Query:  url.Values{"stream": {name}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := "/api/v2/items/" + url.PathEscape(name); escapedPath != expected {
+		t.Errorf("expected path %s, got %s", expected, escapedPath)
+	}
+
+	if stream != name {
+		t.Errorf("expected query value %q, got %q", name, stream)
+	}
+}
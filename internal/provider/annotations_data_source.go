@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &annotationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &annotationsDataSource{}
+)
+
+func AnnotationsDataSource() datasource.DataSource {
+	return &annotationsDataSource{}
+}
+
+type annotationsDataSource struct {
+	client influxdb2.Client
+}
+
+// annotationsDataSourceModel describes the data source data model.
+type annotationsDataSourceModel struct {
+	Id          types.String      `tfsdk:"id"`
+	OrgID       types.String      `tfsdk:"org_id"`
+	Stream      types.String      `tfsdk:"stream"`
+	StartTime   types.String      `tfsdk:"start_time"`
+	EndTime     types.String      `tfsdk:"end_time"`
+	MaxResults  types.Int64       `tfsdk:"max_results"`
+	Annotations []annotationModel `tfsdk:"annotations"`
+}
+
+type annotationModel struct {
+	Id        types.String            `tfsdk:"id"`
+	Stream    types.String            `tfsdk:"stream"`
+	Summary   types.String            `tfsdk:"summary"`
+	StartTime types.String            `tfsdk:"start_time"`
+	EndTime   types.String            `tfsdk:"end_time"`
+	Stickers  map[string]types.String `tfsdk:"stickers"`
+}
+
+// annotationStream is one stream of the annotations list response.
+type annotationStream struct {
+	Stream      string `json:"stream"`
+	Annotations []struct {
+		Id        string            `json:"id"`
+		Summary   string            `json:"summary"`
+		StartTime time.Time         `json:"startTime"`
+		EndTime   time.Time         `json:"endTime"`
+		Stickers  map[string]string `json:"stickers"`
+	} `json:"annotations"`
+}
+
+func (d *annotationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_annotations"
+}
+
+func (d *annotationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Annotations of an InfluxDB Cloud organization, sorted by start time. Requires InfluxDB Cloud.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization id the annotations were read for",
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+			},
+			"stream": schema.StringAttribute{
+				MarkdownDescription: "Only return annotations of this stream",
+				Optional:            true,
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only return annotations ending after this RFC3339 timestamp",
+				Optional:            true,
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only return annotations starting before this RFC3339 timestamp",
+				Optional:            true,
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of annotations to return",
+				Optional:            true,
+			},
+			"annotations": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching annotations",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Annotation id",
+							Computed:            true,
+						},
+						"stream": schema.StringAttribute{
+							MarkdownDescription: "Annotation stream",
+							Computed:            true,
+						},
+						"summary": schema.StringAttribute{
+							MarkdownDescription: "Annotation summary",
+							Computed:            true,
+						},
+						"start_time": schema.StringAttribute{
+							MarkdownDescription: "Annotation start time",
+							Computed:            true,
+						},
+						"end_time": schema.StringAttribute{
+							MarkdownDescription: "Annotation end time",
+							Computed:            true,
+						},
+						"stickers": schema.MapAttribute{
+							MarkdownDescription: "Annotation stickers",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *annotationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// flattenAnnotations sorts the annotations of every stream by start time and
+// keeps at most maxResults of them when maxResults is positive.
+func flattenAnnotations(streams []annotationStream, maxResults int64) []annotationModel {
+	type annotation struct {
+		start time.Time
+		model annotationModel
+	}
+
+	var annotations []annotation
+
+	for _, stream := range streams {
+		for _, item := range stream.Annotations {
+			stickers := map[string]types.String{}
+
+			for key, value := range item.Stickers {
+				stickers[key] = types.StringValue(value)
+			}
+
+			annotations = append(annotations, annotation{
+				start: item.StartTime,
+				model: annotationModel{
+					Id:        types.StringValue(item.Id),
+					Stream:    types.StringValue(stream.Stream),
+					Summary:   types.StringValue(item.Summary),
+					StartTime: types.StringValue(item.StartTime.Format(time.RFC3339Nano)),
+					EndTime:   types.StringValue(item.EndTime.Format(time.RFC3339Nano)),
+					Stickers:  stickers,
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].start.Equal(annotations[j].start) {
+			return annotations[i].model.Id.ValueString() < annotations[j].model.Id.ValueString()
+		}
+
+		return annotations[i].start.Before(annotations[j].start)
+	})
+
+	models := []annotationModel{}
+
+	for _, item := range annotations {
+		if maxResults > 0 && int64(len(models)) >= maxResults {
+			break
+		}
+
+		models = append(models, item.model)
+	}
+
+	return models
+}
+
+func (d *annotationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state annotationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("orgID", state.OrgID.ValueString())
+
+	if !state.Stream.IsNull() {
+		query.Set("streamIncludes", state.Stream.ValueString())
+	}
+
+	bounds := []struct {
+		attribute string
+		parameter string
+		value     types.String
+	}{
+		{"start_time", "startTime", state.StartTime},
+		{"end_time", "endTime", state.EndTime},
+	}
+
+	for _, bound := range bounds {
+		if bound.value.IsNull() {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, bound.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(bound.attribute),
+				"Invalid timestamp",
+				fmt.Sprintf("%s must be an RFC3339 timestamp, got %q", bound.attribute, bound.value.ValueString()),
+			)
+
+			continue
+		}
+
+		query.Set(bound.parameter, bound.value.ValueString())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := influxrest.Do(ctx, d.client, influxrest.Request{
+		Method: http.MethodGet,
+		Path:   "annotations",
+		Query:  query,
+	})
+
+	if err != nil {
+		addCloudAPIError(ctx, &resp.Diagnostics, err,
+			"The influxdbv2_annotations data source",
+			"Error reading annotations",
+			fmt.Sprintf("Could not read annotations for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	var streams []annotationStream
+
+	if err := json.Unmarshal(payload, &streams); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading annotations",
+			fmt.Sprintf("Could not decode annotations for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	state.Id = state.OrgID
+	state.Annotations = flattenAnnotations(streams, state.MaxResults.ValueInt64())
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlattenAnnotations(t *testing.T) {
+	var streams []annotationStream
+
+	err := json.Unmarshal([]byte(`[
+		{"stream":"deploys","annotations":[
+			{"id":"c","summary":"third","startTime":"2024-01-03T00:00:00Z","endTime":"2024-01-03T01:00:00Z"},
+			{"id":"a","summary":"first","startTime":"2024-01-01T00:00:00Z","endTime":"2024-01-01T01:00:00Z","stickers":{"team":"ops"}}
+		]},
+		{"stream":"maintenance","annotations":[
+			{"id":"b","summary":"second","startTime":"2024-01-02T00:00:00Z","endTime":"2024-01-02T04:00:00Z"}
+		]}
+	]`), &streams)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	all := flattenAnnotations(streams, 0)
+
+	if len(all) != 3 || all[0].Id.ValueString() != "a" || all[1].Id.ValueString() != "b" || all[2].Id.ValueString() != "c" {
+		t.Fatalf("expected annotations sorted by start time, got %+v", all)
+	}
+
+	if all[1].Stream.ValueString() != "maintenance" || all[0].Stickers["team"].ValueString() != "ops" {
+		t.Errorf("unexpected annotation mapping: %+v", all)
+	}
+
+	limited := flattenAnnotations(streams, 2)
+
+	if len(limited) != 2 || limited[1].Id.ValueString() != "b" {
+		t.Errorf("expected max_results to keep the two earliest annotations, got %+v", limited)
+	}
+}
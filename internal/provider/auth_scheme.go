@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+const (
+	// authSchemeToken is the "Token <token>" scheme of InfluxDB OSS and
+	// Cloud Serverless, sent by influxdb-client-go.
+	authSchemeToken = "token"
+	// authSchemeBearer is the "Bearer <token>" scheme expected by InfluxDB
+	// Cloud Dedicated.
+	authSchemeBearer = "bearer"
+)
+
+var authSchemes = []string{authSchemeToken, authSchemeBearer}
+
+var authSchemePrefixes = map[string]string{
+	authSchemeToken:  "Token ",
+	authSchemeBearer: "Bearer ",
+}
+
+// authSchemeTransport rewrites the Authorization header set by the client to
+// the configured scheme.
+type authSchemeTransport struct {
+	next   http.RoundTripper
+	scheme string
+}
+
+func (t *authSchemeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header := req.Header.Get("Authorization")
+
+	for _, prefix := range authSchemePrefixes {
+		if !strings.HasPrefix(header, prefix) || prefix == authSchemePrefixes[t.scheme] {
+			continue
+		}
+
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", authSchemePrefixes[t.scheme]+strings.TrimPrefix(header, prefix))
+
+		break
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// detectAuthScheme probes host with the token scheme and falls back to the
+// bearer scheme when the token scheme is rejected with 401 and the bearer
+// scheme is accepted. Any other outcome keeps the token scheme, so invalid
+// credentials are reported as before.
+func detectAuthScheme(ctx context.Context, host string, token string, transport http.RoundTripper) (string, error) {
+	err := probeAuthScheme(ctx, host, token, transport, authSchemeToken)
+
+	var apiErr *influxrest.Error
+
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return authSchemeToken, err
+	}
+
+	if probeAuthScheme(ctx, host, token, transport, authSchemeBearer) == nil {
+		return authSchemeBearer, nil
+	}
+
+	return authSchemeToken, err
+}
+
+func probeAuthScheme(ctx context.Context, host string, token string, transport http.RoundTripper, scheme string) error {
+	client := newInfluxClient(host, token, &authSchemeTransport{next: transport, scheme: scheme})
+	defer client.Close()
+
+	_, err := influxrest.Do(ctx, client, influxrest.Request{
+		Method: http.MethodGet,
+		Path:   "orgs",
+		Query:  url.Values{"limit": []string{"1"}},
+	})
+
+	return err
+}
+
+// withAuthScheme wraps transport to send the token with scheme, detected with
+// detectAuthScheme when empty. The detection error is returned along with the
+// token scheme transport, so callers can carry on.
+func withAuthScheme(ctx context.Context, host string, token string, scheme string, transport http.RoundTripper) (http.RoundTripper, error) {
+	var err error
+
+	if scheme == "" && host != "" && token != "" {
+		scheme, err = detectAuthScheme(ctx, host, token, transport)
+	}
+
+	if scheme == authSchemeBearer {
+		return &authSchemeTransport{next: transport, scheme: scheme}, err
+	}
+
+	return transport, err
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// authServer accepts requests carrying one of the accepted Authorization
+// headers and records the last header it received.
+func authServer(t *testing.T, received *string, accepted ...string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = r.Header.Get("Authorization")
+
+		for _, header := range accepted {
+			if *received == header {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"orgs": []}`))
+
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code": "unauthorized", "message": "unauthorized access"}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAuthSchemeTransport(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		expected string
+	}{
+		{scheme: authSchemeToken, expected: "Token secret"},
+		{scheme: authSchemeBearer, expected: "Bearer secret"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scheme, func(t *testing.T) {
+			var received string
+
+			server := authServer(t, &received, test.expected)
+
+			client := newInfluxClient(server.URL, "secret", &authSchemeTransport{next: http.DefaultTransport, scheme: test.scheme})
+			defer client.Close()
+
+			if _, err := influxrest.Do(context.Background(), client, influxrest.Request{Method: http.MethodGet, Path: "orgs"}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if received != test.expected {
+				t.Errorf("expected Authorization %q, got %q", test.expected, received)
+			}
+		})
+	}
+}
+
+func TestDetectAuthScheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		accepted  []string
+		expected  string
+		expectErr bool
+	}{
+		{name: "token", accepted: []string{"Token secret"}, expected: authSchemeToken},
+		{name: "bearer", accepted: []string{"Bearer secret"}, expected: authSchemeBearer},
+		{name: "both", accepted: []string{"Token secret", "Bearer secret"}, expected: authSchemeToken},
+		{name: "invalid token", expected: authSchemeToken, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received string
+
+			server := authServer(t, &received, test.accepted...)
+
+			scheme, err := detectAuthScheme(context.Background(), server.URL, "secret", http.DefaultTransport)
+
+			if (err != nil) != test.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if scheme != test.expected {
+				t.Errorf("expected scheme %s, got %s", test.expected, scheme)
+			}
+		})
+	}
+}
@@ -0,0 +1,802 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &authorizationResource{}
+var _ resource.ResourceWithImportState = &authorizationResource{}
+var _ resource.ResourceWithValidateConfig = &authorizationResource{}
+var _ resource.ResourceWithModifyPlan = &authorizationResource{}
+
+// authorizationStatuses lists the statuses of an authorization.
+var authorizationStatuses = []string{
+	string(domain.AuthorizationUpdateRequestStatusActive),
+	string(domain.AuthorizationUpdateRequestStatusInactive),
+}
+
+func AuthorizationResource() resource.Resource {
+	return &authorizationResource{}
+}
+
+// authorizationResource defines the resource implementation.
+type authorizationResource struct {
+	providerData *providerData
+}
+
+// authorizationResourceModel describes the resource data model.
+type authorizationResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	OrgID            types.String `tfsdk:"org_id"`
+	UserID           types.String `tfsdk:"user_id"`
+	User             types.String `tfsdk:"user"`
+	Description      types.String `tfsdk:"description"`
+	Status           types.String `tfsdk:"status"`
+	Permissions      types.Set    `tfsdk:"permissions"`
+	Resolved         types.Set    `tfsdk:"resolved_permissions"`
+	AllAccess        types.Bool   `tfsdk:"all_access"`
+	Operator         types.Bool   `tfsdk:"operator"`
+	Token            types.String `tfsdk:"token"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+}
+
+type authorizationPermissionModel struct {
+	Action   types.String                         `tfsdk:"action"`
+	Resource authorizationPermissionResourceModel `tfsdk:"resource"`
+}
+
+type authorizationPermissionResourceModel struct {
+	Type       types.String `tfsdk:"type"`
+	Id         types.String `tfsdk:"id"`
+	OrgID      types.String `tfsdk:"org_id"`
+	BucketName types.String `tfsdk:"bucket_name"`
+}
+
+var authorizationPermissionResourceAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"id":          types.StringType,
+	"org_id":      types.StringType,
+	"bucket_name": types.StringType,
+}
+
+var authorizationPermissionAttrTypes = map[string]attr.Type{
+	"action":   types.StringType,
+	"resource": types.ObjectType{AttrTypes: authorizationPermissionResourceAttrTypes},
+}
+
+func (r *authorizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorization"
+}
+
+func (r *authorizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "API token with a set of permissions. The server cannot change the permissions of a token, so changing them replaces it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Authorization id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the user owning the token, the authenticated user when omitted. The owner cannot be changed, changing it replaces the token.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Name of the user owning the token",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Authorization description",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Authorization status, `active` or `inactive`. Requests using an inactive token are rejected. " +
+					"Changing it keeps the token, unlike changing the permissions.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(domain.AuthorizationUpdateRequestStatusActive)),
+			},
+			"permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions granted by the token. Computed when `all_access` or `operator` is set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+					setplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Permission action, `read` or `write`",
+							Required:            true,
+						},
+						"resource": schema.SingleNestedAttribute{
+							MarkdownDescription: "Resources the permission applies to",
+							Required:            true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Resource type, for example `buckets`",
+									Required:            true,
+								},
+								"id": schema.StringAttribute{
+									MarkdownDescription: "Id of the resource, every resource of the type when omitted",
+									Optional:            true,
+								},
+								"org_id": schema.StringAttribute{
+									MarkdownDescription: "Organization of the resources, every organization when omitted",
+									Optional:            true,
+								},
+								"bucket_name": schema.StringAttribute{
+									MarkdownDescription: "Name of the bucket the permission applies to, resolved when the authorization is created. " +
+										"The resolved id is in `resolved_permissions`. " +
+										"Set `org_id` too when buckets of several organizations have this name.",
+									Optional: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"resolved_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions granted by the token, with the ids of the buckets named by `bucket_name`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Permission action",
+							Computed:            true,
+						},
+						"resource": schema.SingleNestedAttribute{
+							MarkdownDescription: "Resources the permission applies to",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Resource type",
+									Computed:            true,
+								},
+								"id": schema.StringAttribute{
+									MarkdownDescription: "Id of the resource",
+									Computed:            true,
+								},
+								"org_id": schema.StringAttribute{
+									MarkdownDescription: "Organization of the resources",
+									Computed:            true,
+								},
+								"bucket_name": schema.StringAttribute{
+									MarkdownDescription: "Name of the bucket the id was resolved from",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"all_access": schema.BoolAttribute{
+				MarkdownDescription: "Grant read and write access to every resource of the organization, like `influx auth create --all-access`. " +
+					"Conflicts with `permissions` and `operator`.",
+				Optional: true,
+			},
+			"operator": schema.BoolAttribute{
+				MarkdownDescription: "Grant read and write access to every resource of the instance, like `influx auth create --operator`. " +
+					"Conflicts with `permissions` and `all_access`.",
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token of the authorization, only returned by the server when the authorization is created",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that replace the token when they change, like the `keepers` of the random provider. " +
+					"Set it to the `id` of a `time_rotating` resource to rotate the token on a schedule, " +
+					"and `create_before_destroy` so the old token is deleted once the new one is in state.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *authorizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *authorizationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authorizationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Status.IsNull() && !config.Status.IsUnknown() {
+		if err := validateOneOf("status", config.Status.ValueString(), authorizationStatuses); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("status"), "Invalid authorization status", err.Error()+".")
+		}
+	}
+
+	validatePermissions(ctx, config.Permissions, &resp.Diagnostics)
+
+	switch {
+	case config.AllAccess.ValueBool() && config.Operator.ValueBool():
+		resp.Diagnostics.AddAttributeError(path.Root("operator"), "Conflicting permission shortcuts",
+			"Set only one of all_access and operator.")
+	case (config.AllAccess.ValueBool() || config.Operator.ValueBool()) && !config.Permissions.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("permissions"), "Conflicting permissions",
+			"permissions cannot be set together with all_access or operator, which compute the permissions.")
+	case config.Permissions.IsNull() && !config.AllAccess.IsUnknown() && !config.Operator.IsUnknown() &&
+		!config.AllAccess.ValueBool() && !config.Operator.ValueBool():
+		resp.Diagnostics.AddAttributeError(path.Root("permissions"), "Missing permissions",
+			"Set permissions, or all_access or operator to true.")
+	}
+}
+
+// validatePermissions checks the known actions and resource types of the
+// permissions, which the server rejects with a less helpful error at apply
+// time.
+func validatePermissions(ctx context.Context, permissions types.Set, diags *diag.Diagnostics) {
+	if permissions.IsNull() || permissions.IsUnknown() {
+		return
+	}
+
+	for _, element := range permissions.Elements() {
+		object, ok := element.(types.Object)
+
+		if !ok || object.IsUnknown() {
+			continue
+		}
+
+		var permission authorizationPermissionModel
+
+		if object.As(ctx, &permission, basetypes.ObjectAsOptions{}).HasError() {
+			continue
+		}
+
+		elementPath := path.Root("permissions").AtSetValue(element)
+
+		if action := permission.Action; !action.IsUnknown() {
+			if err := validateOneOf("action", action.ValueString(), permissionActions); err != nil {
+				diags.AddAttributeError(elementPath.AtName("action"), "Invalid permission action", err.Error()+".")
+			}
+		}
+
+		if resourceType := permission.Resource.Type; !resourceType.IsUnknown() {
+			if err := validateOneOf("resource type", resourceType.ValueString(), permissionResourceTypes); err != nil {
+				diags.AddAttributeError(elementPath.AtName("resource").AtName("type"), "Invalid permission resource type", err.Error()+".")
+			}
+		}
+	}
+}
+
+// ModifyPlan computes the permissions granted by all_access and operator,
+// so differences from the permissions of the existing token show in the
+// plan. It warns when the provider token cannot manage authorizations.
+func (r *authorizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_authorization", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan authorizationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var permissions []authorizationPermissionModel
+
+	switch {
+	case plan.Operator.ValueBool():
+		permissions = operatorPermissions()
+	case plan.AllAccess.ValueBool() && plan.OrgID.IsUnknown():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permissions"), types.SetUnknown(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}))...)
+
+		return
+	case plan.AllAccess.ValueBool():
+		permissions = allAccessPermissions(plan.OrgID.ValueString())
+	default:
+		return
+	}
+
+	expanded, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, permissions)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permissions"), expanded)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state authorizationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if !state.Permissions.Equal(expanded) {
+		resp.RequiresReplace.Append(path.Root("permissions"))
+	}
+}
+
+// allAccessPermissions returns the read and write permissions on every
+// resource type of the organization with orgID, all but the instance.
+func allAccessPermissions(orgID string) []authorizationPermissionModel {
+	var permissions []authorizationPermissionModel
+
+	for _, resourceType := range permissionResourceTypes {
+		if resourceType == string(domain.ResourceTypeInstance) {
+			continue
+		}
+
+		permissions = append(permissions, readWritePermissions(resourceType, types.StringValue(orgID))...)
+	}
+
+	return permissions
+}
+
+// operatorPermissions returns the read and write permissions on every
+// resource type of every organization.
+func operatorPermissions() []authorizationPermissionModel {
+	var permissions []authorizationPermissionModel
+
+	for _, resourceType := range permissionResourceTypes {
+		permissions = append(permissions, readWritePermissions(resourceType, types.StringNull())...)
+	}
+
+	return permissions
+}
+
+func readWritePermissions(resourceType string, orgID types.String) []authorizationPermissionModel {
+	var permissions []authorizationPermissionModel
+
+	for _, action := range permissionActions {
+		permissions = append(permissions, authorizationPermissionModel{
+			Action: types.StringValue(action),
+			Resource: authorizationPermissionResourceModel{
+				Type:       types.StringValue(resourceType),
+				Id:         types.StringNull(),
+				OrgID:      orgID,
+				BucketName: types.StringNull(),
+			},
+		})
+	}
+
+	return permissions
+}
+
+// authorizationErrorAttributes lists the attributes API validation errors of
+// authorization requests can be attached to.
+var authorizationErrorAttributes = map[string]bool{
+	"org_id":      true,
+	"user_id":     true,
+	"description": true,
+	"status":      true,
+	"permissions": true,
+}
+
+// permissionModels reads the permissions set.
+func permissionModels(ctx context.Context, set types.Set) ([]authorizationPermissionModel, diag.Diagnostics) {
+	var permissions []authorizationPermissionModel
+
+	diags := set.ElementsAs(ctx, &permissions, false)
+
+	return permissions, diags
+}
+
+// resolvePermissionBuckets sets the id of the permissions naming a bucket
+// with bucket_name. A name matching buckets of several organizations is an
+// error unless org_id selects one.
+func resolvePermissionBuckets(ctx context.Context, client influxdb2.Client, permissions []authorizationPermissionModel) error {
+	for i, permission := range permissions {
+		name := permission.Resource.BucketName.ValueString()
+
+		if name == "" {
+			continue
+		}
+
+		params := domain.GetBucketsParams{Name: &name}
+
+		if orgID := permission.Resource.OrgID.ValueString(); orgID != "" {
+			params.OrgID = &orgID
+		}
+
+		buckets, err := influxrest.ListBuckets(ctx, client, params)
+
+		if err != nil {
+			return fmt.Errorf("could not look up bucket %s: %w", name, err)
+		}
+
+		switch {
+		case len(buckets) == 0:
+			return fmt.Errorf("no bucket named %q found", name)
+		case len(buckets) > 1:
+			return fmt.Errorf("%d buckets named %q found in different organizations, set org_id in the permission resource", len(buckets), name)
+		}
+
+		permissions[i].Resource.Id = types.StringPointerValue(buckets[0].Id)
+	}
+
+	return nil
+}
+
+// expandPermissions converts permissions into API permissions.
+func expandPermissions(permissions []authorizationPermissionModel) []domain.Permission {
+	result := make([]domain.Permission, 0, len(permissions))
+
+	for _, permission := range permissions {
+		result = append(result, domain.Permission{
+			Action: domain.PermissionAction(permission.Action.ValueString()),
+			Resource: domain.Resource{
+				Type:  domain.ResourceType(permission.Resource.Type.ValueString()),
+				Id:    stringValueOrNull(permission.Resource.Id.ValueStringPointer()).ValueStringPointer(),
+				OrgID: stringValueOrNull(permission.Resource.OrgID.ValueStringPointer()).ValueStringPointer(),
+			},
+		})
+	}
+
+	return result
+}
+
+// permissionKey identifies a permission by its action and resource.
+func permissionKey(action string, resourceType string, id string) string {
+	return action + "/" + resourceType + "/" + id
+}
+
+// permissionBucketNames indexes the bucket_name of permissions by
+// permissionKey, as the server does not return it.
+func permissionBucketNames(permissions []authorizationPermissionModel) map[string]types.String {
+	names := map[string]types.String{}
+
+	for _, permission := range permissions {
+		key := permissionKey(permission.Action.ValueString(), permission.Resource.Type.ValueString(), permission.Resource.Id.ValueString())
+		names[key] = permission.Resource.BucketName
+	}
+
+	return names
+}
+
+// flattenPermissions converts API permissions into the permissions set,
+// taking bucket_name from bucketNames. The server may reorder permissions,
+// which a set ignores.
+func flattenPermissions(ctx context.Context, permissions []domain.Permission, bucketNames map[string]types.String) (types.Set, diag.Diagnostics) {
+	result := []authorizationPermissionModel{}
+
+	for _, permission := range permissions {
+		id := stringValueOrNull(permission.Resource.Id)
+		bucketName, ok := bucketNames[permissionKey(string(permission.Action), string(permission.Resource.Type), id.ValueString())]
+
+		if !ok {
+			bucketName = types.StringNull()
+		}
+
+		result = append(result, authorizationPermissionModel{
+			Action: types.StringValue(string(permission.Action)),
+			Resource: authorizationPermissionResourceModel{
+				Type:       types.StringValue(string(permission.Resource.Type)),
+				Id:         id,
+				OrgID:      stringValueOrNull(permission.Resource.OrgID),
+				BucketName: bucketName,
+			},
+		})
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, result)
+}
+
+// configuredPermissions returns the resolved permissions as configured,
+// without the ids resolved from bucket_name, so the permissions in state
+// match the configuration.
+func configuredPermissions(ctx context.Context, resolved types.Set) (types.Set, diag.Diagnostics) {
+	permissions, diags := permissionModels(ctx, resolved)
+
+	for i, permission := range permissions {
+		if !permission.Resource.BucketName.IsNull() {
+			permissions[i].Resource.Id = types.StringNull()
+		}
+	}
+
+	result, moreDiags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, permissions)
+	diags.Append(moreDiags...)
+
+	return result, diags
+}
+
+// authorizationToModel maps the server representation of an authorization
+// to the resource model. The token is only set when the server returns it,
+// which it only does on creation. bucket_name is kept from known, the
+// permissions with their resolved ids.
+func authorizationToModel(ctx context.Context, authorization *domain.Authorization, known []authorizationPermissionModel, model *authorizationResourceModel) diag.Diagnostics {
+	var permissions []domain.Permission
+
+	if authorization.Permissions != nil {
+		permissions = *authorization.Permissions
+	}
+
+	resolved, diags := flattenPermissions(ctx, permissions, permissionBucketNames(known))
+	result, moreDiags := configuredPermissions(ctx, resolved)
+	diags.Append(moreDiags...)
+
+	model.Id = types.StringPointerValue(authorization.Id)
+	model.OrgID = types.StringPointerValue(authorization.OrgID)
+	model.UserID = stringValueOrNull(authorization.UserID)
+	model.User = stringValueOrNull(authorization.User)
+	model.Description = stringValueOrNull(authorization.Description)
+	model.Status = stringValueOrNull((*string)(authorization.Status))
+	model.Permissions = result
+	model.Resolved = resolved
+
+	if authorization.Token != nil && *authorization.Token != "" {
+		model.Token = types.StringValue(*authorization.Token)
+	} else if model.Token.IsUnknown() {
+		model.Token = types.StringNull()
+	}
+
+	return diags
+}
+
+func (r *authorizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state authorizationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_authorization", state.Description, state.Id)
+
+	known, diags := permissionModels(ctx, state.Permissions)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := resolvePermissionBuckets(ctx, r.providerData.client, known); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions"),
+			"Error resolving permission bucket",
+			fmt.Sprintf("Could not create authorization %s : %s", state.Description, err),
+		)
+
+		return
+	}
+
+	permissions := expandPermissions(known)
+
+	authorization := domain.Authorization{
+		OrgID:       state.OrgID.ValueStringPointer(),
+		UserID:      stringValueOrNull(state.UserID.ValueStringPointer()).ValueStringPointer(),
+		Permissions: &permissions,
+	}
+	authorization.Description = state.Description.ValueStringPointer()
+
+	authorization.Status = (*domain.AuthorizationUpdateRequestStatus)(state.Status.ValueStringPointer())
+
+	created, err := r.providerData.client.AuthorizationsAPI().CreateAuthorization(ctx, &authorization)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, authorizationErrorAttributes,
+			"Error creating authorization",
+			fmt.Sprintf("Could not create authorization %s : %s", state.Description, err),
+		)
+
+		return
+	}
+
+	if created.Id != nil {
+		r.providerData.recordCreation(*created.Id)
+		ctx = tflog.SetField(ctx, logFieldResourceID, *created.Id)
+	}
+
+	resp.Diagnostics.Append(authorizationToModel(ctx, created, known, &state)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *authorizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state authorizationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_authorization", state.Description, state.Id)
+
+	authorization, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Authorization, error) {
+		return r.providerData.client.APIClient().GetAuthorizationsID(ctx, &domain.GetAuthorizationsIDAllParams{AuthID: state.Id.ValueString()})
+	})
+
+	if isNotFound(err) {
+		tflog.Warn(ctx, "authorization not found, removing it from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading authorization",
+			fmt.Sprintf("Could not read authorization %s with ID %s : %s", state.Description, state.Id, err),
+		)
+
+		return
+	}
+
+	known, diags := permissionModels(ctx, state.Resolved)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(authorizationToModel(ctx, authorization, known, &state)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// updateAuthorization applies the description and status of plan that
+// differ from state. The permissions cannot be changed, a change replaces the
+// authorization.
+func updateAuthorization(ctx context.Context, client influxdb2.Client, plan authorizationResourceModel, state authorizationResourceModel) (*domain.Authorization, error) {
+	id := plan.Id.ValueString()
+
+	if !plan.Description.Equal(state.Description) {
+		description := plan.Description.ValueString()
+
+		_, err := client.APIClient().PatchAuthorizationsID(ctx, &domain.PatchAuthorizationsIDAllParams{
+			AuthID: id,
+			Body:   domain.PatchAuthorizationsIDJSONRequestBody{Description: &description},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !plan.Status.Equal(state.Status) {
+		return client.AuthorizationsAPI().UpdateAuthorizationStatusWithID(ctx, id, domain.AuthorizationUpdateRequestStatus(plan.Status.ValueString()))
+	}
+
+	return client.APIClient().GetAuthorizationsID(ctx, &domain.GetAuthorizationsIDAllParams{AuthID: id})
+}
+
+func (r *authorizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan, state authorizationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_authorization", plan.Description, plan.Id)
+
+	authorization, err := updateAuthorization(ctx, r.providerData.client, plan, state)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, authorizationErrorAttributes,
+			"Error updating authorization",
+			fmt.Sprintf("Could not update authorization %s with ID %s : %s", plan.Description, plan.Id, err),
+		)
+
+		return
+	}
+
+	known, diags := permissionModels(ctx, state.Resolved)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(authorizationToModel(ctx, authorization, known, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *authorizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state authorizationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_authorization", state.Description, state.Id)
+
+	err := r.providerData.client.AuthorizationsAPI().DeleteAuthorizationWithID(ctx, state.Id.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting authorization",
+			fmt.Sprintf("Could not delete authorization %s with ID %s : %s", state.Description, state.Id, err),
+		)
+	}
+}
+
+func (r *authorizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("token"),
+		"Authorization token not imported",
+		"The server only returns the token when the authorization is created, so token stays empty for imported authorizations.",
+	)
+}
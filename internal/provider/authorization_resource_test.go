@@ -0,0 +1,801 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestPermissionsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	bucketID := "0000000000000001"
+	orgID := "0000000000000002"
+
+	permissions := []domain.Permission{
+		{Action: domain.PermissionActionRead, Resource: domain.Resource{Type: domain.ResourceTypeBuckets, Id: &bucketID, OrgID: &orgID}},
+		{Action: domain.PermissionActionWrite, Resource: domain.Resource{Type: domain.ResourceTypeBuckets, Id: &bucketID, OrgID: &orgID}},
+		{Action: domain.PermissionActionRead, Resource: domain.Resource{Type: domain.ResourceTypeOrgs}},
+	}
+
+	set, diags := flattenPermissions(ctx, permissions, nil)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	reordered, _ := flattenPermissions(ctx, []domain.Permission{permissions[2], permissions[1], permissions[0]}, nil)
+
+	if !set.Equal(reordered) {
+		t.Errorf("expected the order of permissions to be ignored, got %s and %s", set, reordered)
+	}
+
+	models, diags := permissionModels(ctx, set)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expanded := expandPermissions(models)
+
+	if len(expanded) != 3 {
+		t.Fatalf("expected 3 permissions, got %+v", expanded)
+	}
+
+	for _, permission := range expanded {
+		if permission.Resource.Type == domain.ResourceTypeOrgs && (permission.Resource.Id != nil || permission.Resource.OrgID != nil) {
+			t.Errorf("expected unset ids to stay unset, got %+v", permission.Resource)
+		}
+	}
+}
+
+func TestAuthorizationToModelKeepsToken(t *testing.T) {
+	ctx := context.Background()
+	id := "0000000000000001"
+	orgID := "0000000000000002"
+	token := "generated"
+	active := domain.AuthorizationUpdateRequestStatusActive
+
+	authorization := &domain.Authorization{Id: &id, OrgID: &orgID, Token: &token}
+	authorization.Status = &active
+
+	model := authorizationResourceModel{Token: types.StringUnknown()}
+
+	if diags := authorizationToModel(ctx, authorization, nil, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if model.Token.ValueString() != token || model.Status.ValueString() != "active" || !model.Description.IsNull() {
+		t.Errorf("unexpected model after create %+v", model)
+	}
+
+	authorization.Token = nil
+
+	if diags := authorizationToModel(ctx, authorization, nil, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if model.Token.ValueString() != token {
+		t.Errorf("expected the token to survive a read without it, got %s", model.Token)
+	}
+}
+
+func TestResolvePermissionBuckets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := []string{"0000000000000001", "0000000000000002"}
+		orgIDs := []string{"000000000000000a", "000000000000000b"}
+
+		buckets := []domain.Bucket{
+			{Id: &ids[0], OrgID: &orgIDs[0], Name: "shared"},
+			{Id: &ids[1], OrgID: &orgIDs[1], Name: "shared"},
+		}
+
+		matches := []domain.Bucket{}
+
+		for _, bucket := range buckets {
+			if orgID := r.URL.Query().Get("orgID"); bucket.Name == r.URL.Query().Get("name") && (orgID == "" || orgID == *bucket.OrgID) {
+				matches = append(matches, bucket)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(domain.Buckets{Buckets: &matches})
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	permission := func(name string, orgID types.String) authorizationPermissionModel {
+		return authorizationPermissionModel{
+			Action: types.StringValue("write"),
+			Resource: authorizationPermissionResourceModel{
+				Type:       types.StringValue("buckets"),
+				Id:         types.StringUnknown(),
+				OrgID:      orgID,
+				BucketName: types.StringValue(name),
+			},
+		}
+	}
+
+	permissions := []authorizationPermissionModel{permission("shared", types.StringValue("000000000000000b"))}
+
+	if err := resolvePermissionBuckets(context.Background(), client, permissions); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if permissions[0].Resource.Id.ValueString() != "0000000000000002" {
+		t.Errorf("expected the bucket of the organization to be resolved, got %s", permissions[0].Resource.Id)
+	}
+
+	for name, expected := range map[string]string{"shared": "set org_id", "missing": "no bucket named"} {
+		err := resolvePermissionBuckets(context.Background(), client, []authorizationPermissionModel{permission(name, types.StringNull())})
+
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, expected, err)
+		}
+	}
+}
+
+func TestFlattenPermissionsKeepsBucketNames(t *testing.T) {
+	ctx := context.Background()
+	bucketID := "0000000000000001"
+
+	known := []authorizationPermissionModel{{
+		Action: types.StringValue("read"),
+		Resource: authorizationPermissionResourceModel{
+			Type:       types.StringValue("buckets"),
+			Id:         types.StringValue(bucketID),
+			OrgID:      types.StringNull(),
+			BucketName: types.StringValue("metrics"),
+		},
+	}}
+
+	set, diags := flattenPermissions(ctx, []domain.Permission{
+		{Action: domain.PermissionActionRead, Resource: domain.Resource{Type: domain.ResourceTypeBuckets, Id: &bucketID}},
+	}, permissionBucketNames(known))
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	models, _ := permissionModels(ctx, set)
+
+	if len(models) != 1 || models[0].Resource.BucketName.ValueString() != "metrics" {
+		t.Errorf("expected bucket_name to be kept, got %+v", models)
+	}
+}
+
+func TestUpdateAuthorization(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		keys := make([]string, 0, len(body))
+
+		for key := range body {
+			keys = append(keys, key)
+		}
+
+		requests = append(requests, strings.TrimSpace(r.Method+" "+strings.Join(keys, ",")))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "status": "inactive", "permissions": []}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	state := authorizationResourceModel{
+		Id:          types.StringValue("0000000000000001"),
+		Description: types.StringValue("ci"),
+		Status:      types.StringValue("active"),
+	}
+
+	for _, test := range []struct {
+		name     string
+		plan     authorizationResourceModel
+		expected []string
+	}{
+		{name: "status", plan: authorizationResourceModel{Id: state.Id, Description: state.Description, Status: types.StringValue("inactive")}, expected: []string{"PATCH status"}},
+		{name: "description", plan: authorizationResourceModel{Id: state.Id, Description: types.StringNull(), Status: state.Status}, expected: []string{"PATCH description", "GET"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			requests = nil
+
+			if _, err := updateAuthorization(context.Background(), client, test.plan, state); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if strings.Join(requests, ";") != strings.Join(test.expected, ";") {
+				t.Errorf("expected requests %v, got %v", test.expected, requests)
+			}
+		})
+	}
+}
+
+// newAuthorizationServer creates authorizations owned by the authenticated
+// user unless the request names one, returning the token only in the create
+// response like the server does, and answers 404 once deleted is set.
+func newAuthorizationServer(t *testing.T, deleted *bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case *deleted:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "not found", "message": "authorization not found"}`))
+		case r.Method == http.MethodPost:
+			var created domain.Authorization
+			_ = json.NewDecoder(r.Body).Decode(&created)
+
+			if created.UserID == nil {
+				created.UserID = &[]string{"0000000000000003"}[0]
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "userID": "` + *created.UserID + `", "user": "operator", "status": "active", "token": "generated", "permissions": [{"action": "read", "resource": {"type": "orgs"}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "userID": "0000000000000003", "user": "operator", "status": "active", "permissions": [{"action": "read", "resource": {"type": "orgs", "name": "ignored"}}]}`))
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func authorizationPlanFor(t *testing.T, model authorizationResourceModel) (tfsdk.Plan, tfsdk.Config) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&authorizationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if model.Resolved.ElementType(ctx) == nil {
+		model.Resolved = types.SetUnknown(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes})
+	}
+
+	if model.RotationTriggers.ElementType(ctx) == nil {
+		model.RotationTriggers = types.MapNull(types.StringType)
+	}
+
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}, tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestAuthorizationValidateConfigShortcuts(t *testing.T) {
+	ctx := context.Background()
+
+	permissions, _ := flattenPermissions(ctx, []domain.Permission{{Action: domain.PermissionActionRead, Resource: domain.Resource{Type: domain.ResourceTypeOrgs}}}, nil)
+	unset := types.SetNull(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes})
+
+	tests := []struct {
+		name        string
+		permissions types.Set
+		allAccess   types.Bool
+		operator    types.Bool
+		expectErr   bool
+	}{
+		{name: "permissions", permissions: permissions},
+		{name: "all access", permissions: unset, allAccess: types.BoolValue(true)},
+		{name: "operator", permissions: unset, operator: types.BoolValue(true)},
+		{name: "unknown shortcut", permissions: unset, allAccess: types.BoolUnknown()},
+		{name: "both shortcuts", permissions: unset, allAccess: types.BoolValue(true), operator: types.BoolValue(true), expectErr: true},
+		{name: "shortcut and permissions", permissions: permissions, allAccess: types.BoolValue(true), expectErr: true},
+		{name: "nothing granted", permissions: unset, allAccess: types.BoolValue(false), expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, config := authorizationPlanFor(t, authorizationResourceModel{
+				OrgID:       types.StringValue("0000000000000002"),
+				Permissions: test.permissions,
+				AllAccess:   test.allAccess,
+				Operator:    test.operator,
+			})
+			resp := resource.ValidateConfigResponse{}
+
+			(&authorizationResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+			if resp.Diagnostics.HasError() != test.expectErr {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAuthorizationValidateConfigPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		action       string
+		resourceType string
+		expected     string
+	}{
+		{name: "valid", action: "write", resourceType: "buckets"},
+		{name: "action", action: "delete", resourceType: "buckets", expected: `action "delete" is not valid`},
+		{name: "resource type", action: "read", resourceType: "bucket", expected: `resource type "bucket" is not valid, expected one of: annotations`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			permissions, _ := flattenPermissions(ctx, []domain.Permission{{
+				Action:   domain.PermissionAction(test.action),
+				Resource: domain.Resource{Type: domain.ResourceType(test.resourceType)},
+			}}, nil)
+
+			_, config := authorizationPlanFor(t, authorizationResourceModel{
+				OrgID:       types.StringValue("0000000000000002"),
+				Permissions: permissions,
+			})
+			resp := resource.ValidateConfigResponse{}
+
+			(&authorizationResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+			if test.expected == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.expected) {
+				t.Errorf("expected an error containing %q, got %v", test.expected, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAuthorizationModifyPlanExpandsShortcuts(t *testing.T) {
+	ctx := context.Background()
+	orgID := "0000000000000002"
+	unset := types.SetNull(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes})
+
+	plan, config := authorizationPlanFor(t, authorizationResourceModel{
+		OrgID:       types.StringValue(orgID),
+		Permissions: unset,
+		AllAccess:   types.BoolValue(true),
+	})
+	resp := resource.ModifyPlanResponse{Plan: plan}
+
+	(&authorizationResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: config, State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil)}}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var planned types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("permissions"), &planned)...)
+
+	models, _ := permissionModels(ctx, planned)
+
+	if expected := 2 * (len(permissionResourceTypes) - 1); len(models) != expected {
+		t.Fatalf("expected %d permissions, got %d", expected, len(models))
+	}
+
+	for _, model := range models {
+		if model.Resource.Type.ValueString() == string(domain.ResourceTypeInstance) || model.Resource.OrgID.ValueString() != orgID {
+			t.Errorf("expected permissions on the resources of organization %s, got %+v", orgID, model)
+		}
+	}
+
+	// An existing token with other permissions is replaced.
+	existing, _ := flattenPermissions(ctx, []domain.Permission{{Action: domain.PermissionActionRead, Resource: domain.Resource{Type: domain.ResourceTypeOrgs}}}, nil)
+	state, _ := authorizationPlanFor(t, authorizationResourceModel{
+		Id:          types.StringValue("0000000000000001"),
+		OrgID:       types.StringValue(orgID),
+		Permissions: existing,
+	})
+	resp = resource.ModifyPlanResponse{Plan: plan}
+
+	(&authorizationResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: config, State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+	if !resp.RequiresReplace.Contains(path.Root("permissions")) {
+		t.Errorf("expected a change of permissions to require replacement, got %v", resp.RequiresReplace)
+	}
+}
+
+func TestAuthorizationTokenSurvivesRead(t *testing.T) {
+	ctx := context.Background()
+	deleted := false
+
+	data := newProviderData(newAuthorizationServer(t, &deleted).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &authorizationResource{providerData: data}
+
+	permissions, _ := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, []authorizationPermissionModel{{
+		Action: types.StringValue("read"),
+		Resource: authorizationPermissionResourceModel{
+			Type:       types.StringValue("orgs"),
+			Id:         types.StringNull(),
+			OrgID:      types.StringNull(),
+			BucketName: types.StringNull(),
+		},
+	}})
+
+	plan, _ := authorizationPlanFor(t, authorizationResourceModel{
+		Id:          types.StringUnknown(),
+		OrgID:       types.StringValue("0000000000000002"),
+		Status:      types.StringValue("active"),
+		Permissions: permissions,
+		UserID:      types.StringUnknown(),
+		User:        types.StringUnknown(),
+		Token:       types.StringUnknown(),
+	})
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var userID types.String
+	createResp.State.GetAttribute(ctx, path.Root("user_id"), &userID)
+
+	if userID.ValueString() != "0000000000000003" {
+		t.Errorf("expected user_id to default to the authenticated user, got %s", userID)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if !readResp.State.Raw.Equal(createResp.State.Raw) {
+		t.Errorf("expected the read to leave the state unchanged, got %s after %s", readResp.State.Raw, createResp.State.Raw)
+	}
+
+	var token types.String
+	readResp.State.GetAttribute(ctx, path.Root("token"), &token)
+
+	if token.ValueString() != "generated" {
+		t.Errorf("expected the token to survive the read, got %s", token)
+	}
+
+	// The authorization was just created, so its removal is only noticed
+	// without the read after create retries.
+	defer func(window time.Duration) { readAfterCreateWindow = window }(readAfterCreateWindow)
+	readAfterCreateWindow = 0
+
+	deleted = true
+	goneResp := resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, &goneResp)
+
+	if goneResp.Diagnostics.HasError() || !goneResp.State.Raw.IsNull() {
+		t.Errorf("expected a deleted authorization to be removed from the state, got %v %s", goneResp.Diagnostics, goneResp.State.Raw)
+	}
+}
+
+func TestAuthorizationCreateForUser(t *testing.T) {
+	ctx := context.Background()
+	deleted := false
+
+	data := newProviderData(newAuthorizationServer(t, &deleted).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	plan, _ := authorizationPlanFor(t, authorizationResourceModel{
+		Id:          types.StringUnknown(),
+		OrgID:       types.StringValue("0000000000000002"),
+		UserID:      types.StringValue("0000000000000004"),
+		User:        types.StringUnknown(),
+		Status:      types.StringValue("active"),
+		Permissions: types.SetValueMust(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, nil),
+		Token:       types.StringUnknown(),
+	})
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	(&authorizationResource{providerData: data}).Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var userID types.String
+	resp.State.GetAttribute(ctx, path.Root("user_id"), &userID)
+
+	if userID.ValueString() != "0000000000000004" {
+		t.Errorf("expected the token to be created for user 0000000000000004, got %s", userID)
+	}
+}
+
+// TestAuthorizationStatusChangePlansUpdate plans deactivating tokens through
+// the provider server, which marks omitted computed attributes unknown before
+// the plan modifiers run, and checks that they are updated in place.
+func TestAuthorizationStatusChangePlansUpdate(t *testing.T) {
+	permission := func(id types.String, bucketName types.String) authorizationPermissionModel {
+		return authorizationPermissionModel{
+			Action: types.StringValue("write"),
+			Resource: authorizationPermissionResourceModel{
+				Type:       types.StringValue("buckets"),
+				Id:         id,
+				OrgID:      types.StringValue("0000000000000002"),
+				BucketName: bucketName,
+			},
+		}
+	}
+
+	setOf := func(permission authorizationPermissionModel) types.Set {
+		set, _ := types.SetValueFrom(context.Background(), types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, []authorizationPermissionModel{permission})
+
+		return set
+	}
+
+	for _, test := range []struct {
+		name       string
+		configured authorizationPermissionModel
+		resolved   authorizationPermissionModel
+	}{
+		{
+			name:       "id",
+			configured: permission(types.StringValue("0000000000000004"), types.StringNull()),
+			resolved:   permission(types.StringValue("0000000000000004"), types.StringNull()),
+		},
+		{
+			name:       "bucket_name",
+			configured: permission(types.StringNull(), types.StringValue("metrics")),
+			resolved:   permission(types.StringValue("0000000000000004"), types.StringValue("metrics")),
+		},
+	} {
+		state := authorizationResourceModel{
+			Id:          types.StringValue("0000000000000001"),
+			OrgID:       types.StringValue("0000000000000002"),
+			UserID:      types.StringValue("0000000000000003"),
+			User:        types.StringValue("operator"),
+			Description: types.StringValue("ci"),
+			Status:      types.StringValue("active"),
+			Permissions: setOf(test.configured),
+			Resolved:    setOf(test.resolved),
+			AllAccess:   types.BoolNull(),
+			Operator:    types.BoolNull(),
+			Token:       types.StringValue("generated"),
+		}
+
+		prior, _ := authorizationPlanFor(t, state)
+
+		proposedModel := state
+		proposedModel.Status = types.StringValue("inactive")
+		proposed, _ := authorizationPlanFor(t, proposedModel)
+
+		configModel := proposedModel
+		configModel.Id, configModel.UserID, configModel.User, configModel.Token = types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull()
+		configModel.Resolved = types.SetNull(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes})
+		config, _ := authorizationPlanFor(t, configModel)
+
+		planned, requiresReplace := planResourceChange(t, "influxdbv2_authorization", prior.Raw, config.Raw, proposed.Raw)
+
+		if len(requiresReplace) != 0 {
+			t.Errorf("%s: expected deactivating the token to update it in place, got replacement for %v", test.name, requiresReplace)
+		}
+
+		if !planned.Equal(proposed.Raw) {
+			t.Errorf("%s: expected the plan to only change the status, got %s", test.name, planned)
+		}
+	}
+}
+
+func TestAuthorizationRotationTriggersPlanReplacement(t *testing.T) {
+	triggers := func(rotatedAt string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"rotated_at": types.StringValue(rotatedAt)})
+	}
+
+	permissions, _ := types.SetValueFrom(context.Background(), types.ObjectType{AttrTypes: authorizationPermissionAttrTypes}, readWritePermissions("buckets", types.StringValue("0000000000000002")))
+
+	state := authorizationResourceModel{
+		Id:               types.StringValue("0000000000000001"),
+		OrgID:            types.StringValue("0000000000000002"),
+		UserID:           types.StringValue("0000000000000003"),
+		User:             types.StringValue("operator"),
+		Description:      types.StringValue("ci"),
+		Status:           types.StringValue("active"),
+		Permissions:      permissions,
+		Resolved:         permissions,
+		AllAccess:        types.BoolNull(),
+		Operator:         types.BoolNull(),
+		Token:            types.StringValue("generated"),
+		RotationTriggers: triggers("2026-01-01T00:00:00Z"),
+	}
+
+	prior, _ := authorizationPlanFor(t, state)
+
+	for _, test := range []struct {
+		name     string
+		triggers types.Map
+		replace  bool
+	}{
+		{name: "unchanged", triggers: triggers("2026-01-01T00:00:00Z")},
+		{name: "changed", triggers: triggers("2026-04-01T00:00:00Z"), replace: true},
+		{name: "removed", triggers: types.MapNull(types.StringType), replace: true},
+	} {
+		proposedModel := state
+		proposedModel.RotationTriggers = test.triggers
+		proposed, _ := authorizationPlanFor(t, proposedModel)
+
+		configModel := proposedModel
+		configModel.Id, configModel.UserID, configModel.User, configModel.Token = types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull()
+		configModel.Status = types.StringNull()
+		configModel.Resolved = types.SetNull(types.ObjectType{AttrTypes: authorizationPermissionAttrTypes})
+		config, _ := authorizationPlanFor(t, configModel)
+
+		planned, requiresReplace := planResourceChange(t, "influxdbv2_authorization", prior.Raw, config.Raw, proposed.Raw)
+
+		replaced := len(requiresReplace) == 1 && requiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("rotation_triggers"))
+
+		if replaced != test.replace || (!test.replace && len(requiresReplace) != 0) {
+			t.Errorf("%s: expected replacement %t, got replacement for %v", test.name, test.replace, requiresReplace)
+		}
+
+		// The token of the existing authorization stays in state until the
+		// replacement creates a new one.
+		var token types.String
+
+		if diags := (tfsdk.Plan{Schema: prior.Schema, Raw: planned}).GetAttribute(context.Background(), path.Root("token"), &token); diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", test.name, diags)
+		}
+
+		if token.ValueString() != "generated" {
+			t.Errorf("%s: expected the stored token to be kept in the plan, got %s", test.name, token)
+		}
+	}
+}
+
+// TestAccAuthorizationResourceKeepsToken applies an authorization, refreshes
+// it and plans it again, and checks the token returned on creation survives
+// unchanged although reads no longer return it.
+func TestAccAuthorizationResourceKeepsToken(t *testing.T) {
+	config := testAccProviderConfig() + fmt.Sprintf(`
+resource "influxdbv2_bucket" "test" {
+  name   = %q
+  org_id = data.influxdbv2_organization.test.id
+}
+
+resource "influxdbv2_authorization" "test" {
+  org_id      = data.influxdbv2_organization.test.id
+  description = "acceptance test token"
+
+  permissions = [{
+    action   = "read"
+    resource = { type = "buckets", id = influxdbv2_bucket.test.id, org_id = data.influxdbv2_organization.test.id }
+  }]
+}
+`, acctest.RandomWithPrefix("tf-acc-authorization"))
+
+	var token string
+
+	sameToken := resourcetest.TestCheckResourceAttrWith("influxdbv2_authorization.test", "token", func(value string) error {
+		if value == "" || value != token {
+			return fmt.Errorf("expected the token created first, got a different value")
+		}
+
+		return nil
+	})
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config,
+				Check: resourcetest.TestCheckResourceAttrWith("influxdbv2_authorization.test", "token", func(value string) error {
+					if value == "" {
+						return errors.New("expected the created token in the state")
+					}
+
+					token = value
+
+					return nil
+				}),
+			},
+			{
+				RefreshState: true,
+				Check:        sameToken,
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check:  sameToken,
+			},
+		},
+	})
+}
+
+func testAccAuthorizationRotationConfig(name string, rotation string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "influxdbv2_bucket" "test" {
+  name   = %q
+  org_id = data.influxdbv2_organization.test.id
+}
+
+resource "influxdbv2_authorization" "test" {
+  org_id = data.influxdbv2_organization.test.id
+
+  permissions = [{
+    action   = "read"
+    resource = { type = "buckets", id = influxdbv2_bucket.test.id, org_id = data.influxdbv2_organization.test.id }
+  }]
+
+  rotation_triggers = {
+    rotation = %q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, name, rotation)
+}
+
+// TestAccAuthorizationResourceRotation changes rotation_triggers and checks
+// the authorization is replaced with a new token, creating the new one
+// before destroying the old one.
+func TestAccAuthorizationResourceRotation(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-rotation")
+
+	var token string
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: testAccAuthorizationRotationConfig(name, "1"),
+				Check: resourcetest.TestCheckResourceAttrWith("influxdbv2_authorization.test", "token", func(value string) error {
+					token = value
+
+					return nil
+				}),
+			},
+			{
+				Config: testAccAuthorizationRotationConfig(name, "2"),
+				ConfigPlanChecks: resourcetest.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("influxdbv2_authorization.test", plancheck.ResourceActionCreateBeforeDestroy),
+					},
+				},
+				Check: resourcetest.TestCheckResourceAttrWith("influxdbv2_authorization.test", "token", func(value string) error {
+					if value == "" || value == token {
+						return errors.New("expected a new token after rotation")
+					}
+
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// unsupportedBackendError describes a server that does not implement the
+// InfluxDB 2.x management API.
+type unsupportedBackendError struct {
+	backend string
+}
+
+func (e *unsupportedBackendError) Error() string {
+	return fmt.Sprintf("unsupported backend: %s", e.backend)
+}
+
+// detectUnsupportedBackend identifies InfluxDB 1.x and 3.x servers from the
+// version header of /ping, and other servers answering /api/v2 without the
+// InfluxDB route list, such as proxies. It returns an
+// *unsupportedBackendError for those, and the request error when the server
+// cannot be reached, which callers may ignore.
+func detectUnsupportedBackend(ctx context.Context, client influxdb2.Client) error {
+	ping, err := pingServer(ctx, client)
+
+	if err == nil && ping.version != "" {
+		version := strings.TrimPrefix(ping.version, "v")
+		major, _, _ := strings.Cut(version, ".")
+
+		switch major {
+		case "2":
+			return nil
+		case "1", "3":
+			backend := fmt.Sprintf("InfluxDB %s", version)
+
+			if ping.build != "" {
+				backend = fmt.Sprintf("InfluxDB %s %s", version, ping.build)
+			}
+
+			return &unsupportedBackendError{backend: backend}
+		}
+	}
+
+	if err == nil && strings.HasPrefix(strings.ToLower(ping.build), "cloud") {
+		return nil
+	}
+
+	payload, err := getAPIRoutes(ctx, client)
+
+	if err != nil {
+		return err
+	}
+
+	var routes map[string]json.RawMessage
+
+	if payload == nil || json.Unmarshal(payload, &routes) != nil {
+		return &unsupportedBackendError{backend: "a server answering /api/v2 with something other than the InfluxDB route list"}
+	}
+
+	return nil
+}
+
+// getAPIRoutes returns the body of GET /api/v2, or nil when the server
+// answers with a client error other than an authentication failure.
+func getAPIRoutes(ctx context.Context, client influxdb2.Client) ([]byte, error) {
+	api := client.APIClient()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(api.APIEndpoint, "/"), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := api.Client.Do(httpReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rsp.Body.Close() }()
+
+	if rsp.StatusCode >= 400 && rsp.StatusCode < 500 && rsp.StatusCode != http.StatusUnauthorized && rsp.StatusCode != http.StatusForbidden {
+		return nil, nil
+	}
+
+	payload, err := io.ReadAll(rsp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode >= 300 {
+		return nil, influxrest.DecodeError(rsp, payload)
+	}
+
+	return payload, nil
+}
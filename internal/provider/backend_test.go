@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectUnsupportedBackend(t *testing.T) {
+	for name, test := range map[string]struct {
+		version     string
+		build       string
+		routes      string
+		unsupported bool
+	}{
+		"oss 2.x":         {version: "v2.7.5", build: "OSS"},
+		"cloud":           {build: "cloud2"},
+		"1.x":             {version: "1.8.10", build: "OSS", unsupported: true},
+		"3.x core":        {version: "3.0.1", build: "Core", unsupported: true},
+		"no version":      {routes: `{"orgs":"/api/v2/orgs"}`},
+		"proxy 404":       {unsupported: true},
+		"proxy html":      {routes: `<html>hello</html>`, unsupported: true},
+		"unknown version": {version: "9.0", routes: `{"orgs":"/api/v2/orgs"}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/ping":
+					if test.version != "" {
+						w.Header().Set("X-Influxdb-Version", test.version)
+					}
+
+					if test.build != "" {
+						w.Header().Set("X-Influxdb-Build", test.build)
+					}
+
+					w.WriteHeader(http.StatusNoContent)
+				case r.URL.Path == "/api/v2" && test.routes != "":
+					_, _ = w.Write([]byte(test.routes))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+			defer client.Close()
+
+			err := detectUnsupportedBackend(context.Background(), client)
+
+			var unsupported *unsupportedBackendError
+
+			if errors.As(err, &unsupported) != test.unsupported {
+				t.Errorf("expected unsupported = %t, got %v", test.unsupported, err)
+			}
+		})
+	}
+}
+
+func TestDetectUnsupportedBackendUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	err := detectUnsupportedBackend(context.Background(), client)
+
+	var unsupported *unsupportedBackendError
+
+	if err == nil || errors.As(err, &unsupported) {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
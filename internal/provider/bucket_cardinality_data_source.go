@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &bucketCardinalityDataSource{}
+	_ datasource.DataSourceWithConfigure = &bucketCardinalityDataSource{}
+)
+
+func BucketCardinalityDataSource() datasource.DataSource {
+	return &bucketCardinalityDataSource{}
+}
+
+type bucketCardinalityDataSource struct {
+	client influxdb2.Client
+}
+
+// bucketCardinalityDataSourceModel describes the data source data model.
+type bucketCardinalityDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Org         types.String `tfsdk:"org"`
+	Bucket      types.String `tfsdk:"bucket"`
+	Start       types.String `tfsdk:"start"`
+	Stop        types.String `tfsdk:"stop"`
+	Cardinality types.Int64  `tfsdk:"cardinality"`
+}
+
+func (d *bucketCardinalityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_cardinality"
+}
+
+func (d *bucketCardinalityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Series cardinality of a bucket, computed with the Flux `influxdb.cardinality` function.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Bucket the cardinality was computed for",
+				Computed:            true,
+			},
+			"org": schema.StringAttribute{
+				MarkdownDescription: "Organization name or id",
+				Required:            true,
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Bucket name",
+				Required:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Earliest time of the series counted, a duration such as `-30d` or an RFC3339 timestamp. Defaults to the earliest time InfluxDB can store.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"stop": schema.StringAttribute{
+				MarkdownDescription: "Latest time of the series counted, a duration or an RFC3339 timestamp. Defaults to the latest time InfluxDB can store.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"cardinality": schema.Int64Attribute{
+				MarkdownDescription: "Number of series in the time range",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *bucketCardinalityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// cardinalityQuery returns the Flux query counting the series of bucket
+// between start and stop.
+func cardinalityQuery(bucket string, start string, stop string) (string, error) {
+	startLiteral, err := fluxTime(start)
+
+	if err != nil {
+		return "", fmt.Errorf("start: %w", err)
+	}
+
+	stopLiteral, err := fluxTime(stop)
+
+	if err != nil {
+		return "", fmt.Errorf("stop: %w", err)
+	}
+
+	return fmt.Sprintf("import \"influxdata/influxdb\"\n\ninfluxdb.cardinality(bucket: %s, start: %s, stop: %s)",
+		fluxString(bucket), startLiteral, stopLiteral), nil
+}
+
+// queryCardinality runs query and returns the series count it reports, zero
+// for a bucket without series.
+func queryCardinality(ctx context.Context, client influxdb2.Client, org string, query string) (int64, error) {
+	values, err := queryValues(ctx, client, org, query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var cardinality int64
+
+	for _, value := range values {
+		count, ok := value.(int64)
+
+		if !ok {
+			return 0, fmt.Errorf("unexpected cardinality value %v of type %T", value, value)
+		}
+
+		cardinality += count
+	}
+
+	return cardinality, nil
+}
+
+func (d *bucketCardinalityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state bucketCardinalityDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Start.IsNull() {
+		state.Start = types.StringValue(bucketMinTime.Format(time.RFC3339Nano))
+	}
+
+	if state.Stop.IsNull() {
+		state.Stop = types.StringValue(bucketMaxTime.Format(time.RFC3339Nano))
+	}
+
+	for attribute, value := range map[string]types.String{"start": state.Start, "stop": state.Stop} {
+		if _, err := fluxTime(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid time range", err.Error())
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := cardinalityQuery(state.Bucket.ValueString(), state.Start.ValueString(), state.Stop.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid time range", err.Error())
+
+		return
+	}
+
+	cardinality, err := queryCardinality(ctx, d.client, state.Org.ValueString(), query)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading bucket cardinality",
+			fmt.Sprintf("Could not compute the cardinality of bucket %s : %s\n\n"+
+				"InfluxDB Cloud may reject cardinality queries over long time ranges; set start and stop to a narrower window if the query was rejected.",
+				state.Bucket, err),
+		)
+
+		return
+	}
+
+	state.Id = state.Bucket
+	state.Cardinality = types.Int64Value(cardinality)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testFluxCardinalityCSV is the annotated CSV response of influxdb.cardinality.
+const testFluxCardinalityCSV = `#datatype,string,long,long
+#group,false,false,false
+#default,_result,,
+,result,table,_value
+,,0,1234
+
+`
+
+func TestCardinalityQuery(t *testing.T) {
+	query, err := cardinalityQuery("metrics", "-30d", "2024-01-01T00:00:00Z")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "import \"influxdata/influxdb\"\n\ninfluxdb.cardinality(bucket: \"metrics\", start: -30d, stop: 2024-01-01T00:00:00Z)"
+
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	if _, err := cardinalityQuery("metrics", "-30d", "now"); err == nil || !strings.HasPrefix(err.Error(), "stop: ") {
+		t.Errorf("expected an invalid stop to be rejected, got %v", err)
+	}
+}
+
+func TestQueryCardinality(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected int64
+	}{
+		{name: "series", body: testFluxCardinalityCSV, expected: 1234},
+		{name: "empty bucket", body: "", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newInfluxClient(newFluxQueryServer(t, http.StatusOK, test.body).URL, "token", http.DefaultTransport)
+			defer client.Close()
+
+			cardinality, err := queryCardinality(context.Background(), client, "org", "query")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if cardinality != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, cardinality)
+			}
+		})
+	}
+}
+
+func TestQueryCardinalityRejected(t *testing.T) {
+	body := `{"code":"invalid","message":"query time range exceeds the allowed maximum"}`
+	client := newInfluxClient(newFluxQueryServer(t, http.StatusBadRequest, body).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	_, err := queryCardinality(context.Background(), client, "org", "query")
+
+	if err == nil || !strings.Contains(err.Error(), "query time range exceeds the allowed maximum") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
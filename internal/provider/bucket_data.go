@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+var (
+	// bucketMinTime and bucketMaxTime are the earliest and latest timestamps
+	// InfluxDB can store, so a range between them covers all data.
+	bucketMinTime = time.Unix(0, math.MinInt64+2).UTC()
+	bucketMaxTime = time.Unix(0, math.MaxInt64-1).UTC()
+
+	// bucketPurgePollInterval is how often a purge is checked for completion.
+	bucketPurgePollInterval = time.Second
+
+	// bucketDataCheckTimeout bounds the query checking whether a bucket holds
+	// data, so an overloaded server cannot hang a destroy.
+	bucketDataCheckTimeout = 30 * time.Second
+)
+
+// bucketHasData reports whether the bucket holds at least one point.
+func bucketHasData(ctx context.Context, client influxdb2.Client, orgID string, bucketID string) (bool, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, bucketDataCheckTimeout)
+	defer cancel()
+
+	query := fmt.Sprintf(
+		`from(bucketID: %q) |> range(start: %s, stop: %s) |> limit(n: 1)`,
+		bucketID,
+		bucketMinTime.Format(time.RFC3339Nano),
+		bucketMaxTime.Format(time.RFC3339Nano),
+	)
+
+	result, err := client.QueryAPI(orgID).Query(checkCtx, query)
+
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+			return false, fmt.Errorf("the data check query did not complete within %s", bucketDataCheckTimeout)
+		}
+
+		return false, err
+	}
+
+	defer result.Close()
+
+	hasData := result.Next()
+
+	return hasData, result.Err()
+}
+
+// purgeBucketData deletes every point in the bucket and waits until the
+// bucket reports no data, or ctx is done.
+func purgeBucketData(ctx context.Context, client influxdb2.Client, orgID string, bucketID string) error {
+	err := client.DeleteAPI().DeleteWithID(ctx, orgID, bucketID, bucketMinTime, bucketMaxTime, "")
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		hasData, err := bucketHasData(ctx, client, orgID, bucketID)
+
+		if err != nil {
+			return err
+		}
+
+		if !hasData {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(bucketPurgePollInterval):
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testFluxRowCSV is an annotated CSV query response holding a single point.
+const testFluxRowCSV = `#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,double,string,string
+#group,false,false,true,true,false,false,true,true
+#default,_result,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement
+,,0,2020-01-01T00:00:00Z,2021-01-01T00:00:00Z,2020-06-01T00:00:00Z,1,value,cpu
+
+`
+
+// newBucketDataServer fakes the query and delete endpoints of a bucket whose
+// data is removed by a delete request.
+func newBucketDataServer(t *testing.T, hasData *bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/query":
+			w.Header().Set("Content-Type", "text/csv")
+
+			if *hasData {
+				_, _ = w.Write([]byte(testFluxRowCSV))
+			}
+		case "/api/v2/delete":
+			*hasData = false
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBucketHasData(t *testing.T) {
+	for _, populated := range []bool{false, true} {
+		hasData := populated
+		client := newInfluxClient(newBucketDataServer(t, &hasData).URL, "token", http.DefaultTransport)
+
+		got, err := bucketHasData(context.Background(), client, "0000000000000001", "0000000000000002")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != populated {
+			t.Errorf("expected bucketHasData to return %t, got %t", populated, got)
+		}
+
+		client.Close()
+	}
+}
+
+func TestPurgeBucketData(t *testing.T) {
+	hasData := true
+	client := newInfluxClient(newBucketDataServer(t, &hasData).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := purgeBucketData(ctx, client, "0000000000000001", "0000000000000002"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if hasData {
+		t.Error("expected the purge to delete the bucket data")
+	}
+}
+
+func TestBucketHasDataTimeout(t *testing.T) {
+	previous := bucketDataCheckTimeout
+	bucketDataCheckTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { bucketDataCheckTimeout = previous })
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	_, err := bucketHasData(context.Background(), client, "0000000000000001", "0000000000000002")
+
+	if err == nil || !strings.Contains(err.Error(), "did not complete within 50ms") {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+}
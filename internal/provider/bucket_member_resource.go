@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &bucketMemberResource{}
+var _ resource.ResourceWithImportState = &bucketMemberResource{}
+var _ resource.ResourceWithValidateConfig = &bucketMemberResource{}
+var _ resource.ResourceWithModifyPlan = &bucketMemberResource{}
+
+func BucketMemberResource() resource.Resource {
+	return &bucketMemberResource{}
+}
+
+// bucketMemberResource defines the resource implementation.
+type bucketMemberResource struct {
+	providerData *providerData
+}
+
+// bucketMemberResourceModel describes the resource data model.
+type bucketMemberResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	BucketID types.String `tfsdk:"bucket_id"`
+	UserID   types.String `tfsdk:"user_id"`
+	Role     types.String `tfsdk:"role"`
+}
+
+func (r *bucketMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_member"
+}
+
+func (r *bucketMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Membership of a user in a bucket. Import with `<bucket_id>/<user_id>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Membership id, `<bucket_id>/<user_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				MarkdownDescription: "Bucket id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the member",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role of the user, `member` or `owner`. Changing it grants the new role before revoking the old one. " +
+					"Creating a `member` for a user that already is an owner fails instead of revoking the ownership.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(membershipRoleMember),
+			},
+		},
+	}
+}
+
+func (r *bucketMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *bucketMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var role types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+
+	if resp.Diagnostics.HasError() || role.IsNull() || role.IsUnknown() {
+		return
+	}
+
+	if err := validateOneOf("role", role.ValueString(), membershipRoles); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Invalid membership role", err.Error()+".")
+	}
+}
+
+// ModifyPlan warns when the provider token cannot manage the members of buckets.
+func (r *bucketMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_bucket_member", &resp.Diagnostics)
+}
+
+func (r *bucketMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state bucketMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(membershipID(state.BucketID.ValueString(), state.UserID.ValueString()))
+
+	ctx = withObjectFields(ctx, "influxdbv2_bucket_member", state.UserID, state.Id)
+
+	role, err := bucketMemberships(r.providerData.client).adopt(ctx, state.BucketID.ValueString(), state.UserID.ValueString(), state.Role.ValueString())
+
+	if errors.Is(err, errOwnerDowngrade) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Refusing to demote an existing owner",
+			fmt.Sprintf("User %s is an owner of bucket %s. Set role = \"owner\" to manage the existing ownership, "+
+				"or remove the ownership before adding the user as a member.", state.UserID, state.BucketID),
+		)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error adding bucket member",
+			fmt.Sprintf("Could not add user %s to bucket %s : %s", state.UserID, state.BucketID, err),
+		)
+
+		return
+	}
+
+	if role != "" {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing membership",
+			fmt.Sprintf("User %s already was %s of bucket %s, the membership is now managed by this resource.", state.UserID, role, state.BucketID),
+		)
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *bucketMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state bucketMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_bucket_member", state.UserID, state.Id)
+
+	role, err := bucketMemberships(r.providerData.client).role(ctx, state.BucketID.ValueString(), state.UserID.ValueString())
+
+	if isNotFound(err) || (err == nil && role == "") {
+		tflog.Warn(ctx, "bucket membership not found, removing it from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading bucket members",
+			fmt.Sprintf("Could not read the members of bucket %s : %s", state.BucketID, err),
+		)
+
+		return
+	}
+
+	state.Role = types.StringValue(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *bucketMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan, state bucketMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_bucket_member", plan.UserID, plan.Id)
+
+	err := bucketMemberships(r.providerData.client).setRole(ctx, plan.BucketID.ValueString(), plan.UserID.ValueString(), state.Role.ValueString(), plan.Role.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error changing bucket member role",
+			fmt.Sprintf("Could not change the role of user %s in bucket %s : %s", plan.UserID, plan.BucketID, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *bucketMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state bucketMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_bucket_member", state.UserID, state.Id)
+
+	err := bucketMemberships(r.providerData.client).revoke(ctx, state.BucketID.ValueString(), state.UserID.ValueString(), state.Role.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error removing bucket member",
+			fmt.Sprintf("Could not remove user %s from bucket %s : %s", state.UserID, state.BucketID, err),
+		)
+	}
+}
+
+func (r *bucketMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	bucketID, userID, err := parseMembershipID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error()+".")
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket_id"), bucketID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestBucketMemberLifecycle(t *testing.T) {
+	ctx := context.Background()
+	members := map[string]string{}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/buckets/0000000000000001", members).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &bucketMemberResource{providerData: data}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	plan.Set(ctx, &bucketMemberResourceModel{
+		Id:       types.StringUnknown(),
+		BucketID: types.StringValue("0000000000000001"),
+		UserID:   types.StringValue("0000000000000002"),
+		Role:     types.StringValue(membershipRoleMember),
+	})
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() || members["0000000000000002"] != membershipRoleMember {
+		t.Fatalf("expected user 0000000000000002 to be a member, got %v", createResp.Diagnostics)
+	}
+
+	// A membership revoked outside Terraform is forgotten, and deleting it
+	// again succeeds.
+	delete(members, "0000000000000002")
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected a revoked membership to be removed from the state, got %v %s", readResp.Diagnostics, readResp.State.Raw)
+	}
+}
+
+// TestAccBucketMemberResource adds a user created outside of Terraform, the
+// provider has no user resource, to a bucket managed alongside, then imports
+// the membership.
+func TestAccBucketMemberResource(t *testing.T) {
+	if os.Getenv(resourcetest.EnvTfAcc) == "" {
+		t.Skipf("acceptance tests skipped unless %s is set", resourcetest.EnvTfAcc)
+	}
+
+	testAccPreCheck(t)
+
+	client, _ := testAccClient(t)
+
+	user, err := client.UsersAPI().CreateUserWithName(context.Background(), acctest.RandomWithPrefix("tf-acc-member"))
+
+	if err != nil {
+		t.Fatalf("could not create the member user: %s", err)
+	}
+
+	t.Cleanup(func() { _ = client.UsersAPI().DeleteUser(context.Background(), user) })
+
+	config := testAccProviderConfig() + fmt.Sprintf(`
+resource "influxdbv2_bucket" "test" {
+  name   = %q
+  org_id = data.influxdbv2_organization.test.id
+}
+
+resource "influxdbv2_bucket_member" "test" {
+  bucket_id = influxdbv2_bucket.test.id
+  user_id   = %q
+}
+`, acctest.RandomWithPrefix("tf-acc-member"), *user.Id)
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config,
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("influxdbv2_bucket_member.test", "user_id", *user.Id),
+					resourcetest.TestCheckResourceAttr("influxdbv2_bucket_member.test", "role", membershipRoleMember),
+					resourcetest.TestCheckResourceAttrPair("influxdbv2_bucket_member.test", "bucket_id", "influxdbv2_bucket.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "influxdbv2_bucket_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -7,18 +7,28 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &bucketResource{}
 var _ resource.ResourceWithImportState = &bucketResource{}
+var _ resource.ResourceWithModifyPlan = &bucketResource{}
+var _ resource.ResourceWithValidateConfig = &bucketResource{}
 
 func BucketResource() resource.Resource {
 	return &bucketResource{}
@@ -26,20 +36,27 @@ func BucketResource() resource.Resource {
 
 // bucketResource defines the resource implementation.
 type bucketResource struct {
-	client influxdb2.Client
+	providerData *providerData
 }
 
 // bucketResourceModel describes the resource data model.
 type bucketResourceModel struct {
-	Name          types.String                `tfsdk:"name"`
-	Id            types.String                `tfsdk:"id"`
-	OrgID         types.String                `tfsdk:"org_id"`
-	Description   types.String                `tfsdk:"description"`
-	RetentioRules []bucketRetentionRulesModel `tfsdk:"retention_rules"`
-	RP            types.String                `tfsdk:"rp"`
-	ScehmaType    types.String                `tfsdk:"schema_type"`
-	CreatedAt     types.String                `tfsdk:"created_at"`
-	UpdatedAt     types.String                `tfsdk:"updated_at"`
+	Name            types.String   `tfsdk:"name"`
+	Id              types.String   `tfsdk:"id"`
+	OrgID           types.String   `tfsdk:"org_id"`
+	Description     types.String   `tfsdk:"description"`
+	RetentioRules   types.List     `tfsdk:"retention_rules"`
+	RetentionRule   types.List     `tfsdk:"retention_rule"`
+	RP              types.String   `tfsdk:"rp"`
+	ScehmaType      types.String   `tfsdk:"schema_type"`
+	CreatedAt       types.String   `tfsdk:"created_at"`
+	UpdatedAt       types.String   `tfsdk:"updated_at"`
+	Token           types.String   `tfsdk:"token"`
+	ForceDestroy    types.Bool     `tfsdk:"force_destroy"`
+	RetainOnDelete  types.Bool     `tfsdk:"retain_on_delete"`
+	EffectiveLabels types.Set      `tfsdk:"effective_labels"`
+	AdoptExisting   types.Bool     `tfsdk:"adopt_existing"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 type bucketRetentionRulesModel struct {
@@ -47,6 +64,142 @@ type bucketRetentionRulesModel struct {
 	RetentionType types.String `tfsdk:"retention_type"`
 }
 
+var bucketRetentionRulesAttrTypes = map[string]attr.Type{
+	"every_seconds":  types.Int64Type,
+	"retention_type": types.StringType,
+}
+
+// bucketErrorAttributes lists the attributes API validation errors of
+// bucket requests can be attached to.
+var bucketErrorAttributes = map[string]bool{
+	"name":            true,
+	"description":     true,
+	"org_id":          true,
+	"retention_rules": true,
+	"schema_type":     true,
+}
+
+// expandRetentionRules converts the retention_rules list into API rules. Null
+// or unknown lists yield no rules so that the server default applies.
+func expandRetentionRules(ctx context.Context, list types.List) ([]domain.RetentionRule, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	var rules []bucketRetentionRulesModel
+
+	diags := list.ElementsAs(ctx, &rules, false)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var retentionRules []domain.RetentionRule
+
+	for _, rule := range rules {
+		retentionRules = append(retentionRules, domain.RetentionRule{
+			EverySeconds: rule.EverySeconds.ValueInt64(),
+			Type:         (*domain.RetentionRuleType)(rule.RetentionType.ValueStringPointer()),
+		})
+	}
+
+	return retentionRules, diags
+}
+
+// flattenRetentionRules converts API retention rules into the retention_rules list.
+func flattenRetentionRules(ctx context.Context, retentionRules []domain.RetentionRule) (types.List, diag.Diagnostics) {
+	rules := []bucketRetentionRulesModel{}
+
+	for _, rule := range retentionRules {
+		rules = append(rules, bucketRetentionRulesModel{
+			EverySeconds:  types.Int64Value(rule.EverySeconds),
+			RetentionType: types.StringPointerValue((*string)(rule.Type)),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}, rules)
+}
+
+// bucketToModel maps every server side bucket field into the resource model.
+func bucketToModel(ctx context.Context, bucket *domain.Bucket, model *bucketResourceModel) diag.Diagnostics {
+	retentionRules, diags := flattenRetentionRules(ctx, bucket.RetentionRules)
+
+	model.Id = types.StringPointerValue(bucket.Id)
+	model.Name = types.StringValue(bucket.Name)
+	model.Description = stringValueOrNull(bucket.Description)
+	model.OrgID = types.StringPointerValue(bucket.OrgID)
+	model.RP = stringValueOrNull(bucket.Rp)
+	model.RetentioRules = retentionRules
+	model.ScehmaType = stringValueOrNull((*string)(bucket.SchemaType))
+	model.CreatedAt = timeValue(bucket.CreatedAt)
+	model.UpdatedAt = timeValue(bucket.UpdatedAt)
+
+	effectiveLabels, labelDiags := flattenLabels(ctx, bucket.Labels)
+	model.EffectiveLabels = effectiveLabels
+	diags.Append(labelDiags...)
+
+	return diags
+}
+
+// applyDefaultLabels attaches the provider default_labels missing from bucket
+// and returns the bucket as stored afterwards.
+func (r *bucketResource) applyDefaultLabels(ctx context.Context, client influxdb2.Client, bucket *domain.Bucket) (*domain.Bucket, error) {
+	if len(r.providerData.defaultLabels) == 0 || bucket.Id == nil || bucket.OrgID == nil {
+		return bucket, nil
+	}
+
+	err := attachDefaultLabels(ctx, client, *bucket.OrgID, r.providerData.defaultLabels, bucket.Labels, func(labelID string) error {
+		return addBucketLabel(ctx, client, *bucket.Id, labelID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return findAfterCreate(ctx, r.providerData, *bucket.Id, func(ctx context.Context) (*domain.Bucket, error) {
+		return client.BucketsAPI().FindBucketByID(ctx, *bucket.Id)
+	})
+}
+
+// adoptBucket finds the bucket named like bucket in the organization of
+// bucket and updates it to match bucket. Buckets of other organizations are
+// never adopted, nor buckets whose immutable settings differ.
+func adoptBucket(ctx context.Context, client influxdb2.Client, bucket domain.Bucket) (*domain.Bucket, error) {
+	orgID := *bucket.OrgID
+
+	buckets, err := influxrest.ListBuckets(ctx, client, domain.GetBucketsParams{OrgID: &orgID, Name: &bucket.Name})
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := selectByName("bucket", orgID, bucket.Name, buckets,
+		func(b domain.Bucket) string { return b.Name },
+		func(b domain.Bucket) string { return stringValueOrNull(b.Id).ValueString() },
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.OrgID == nil || *existing.OrgID != orgID {
+		return nil, fmt.Errorf("bucket %s belongs to another organization", bucket.Name)
+	}
+
+	if bucket.SchemaType != nil && (existing.SchemaType == nil || *existing.SchemaType != *bucket.SchemaType) {
+		return nil, fmt.Errorf("the existing bucket %s has schema_type %s, which cannot be changed", bucket.Name, stringValueOrNull((*string)(existing.SchemaType)).ValueString())
+	}
+
+	if bucket.Rp != nil && (existing.Rp == nil || *existing.Rp != *bucket.Rp) {
+		return nil, fmt.Errorf("the existing bucket %s has rp %s, which cannot be changed", bucket.Name, stringValueOrNull(existing.Rp).ValueString())
+	}
+
+	existing.Description = bucket.Description
+	existing.RetentionRules = bucket.RetentionRules
+
+	return client.BucketsAPI().UpdateBucket(ctx, &existing)
+}
+
 func (r *bucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_bucket"
 }
@@ -65,11 +218,17 @@ func (r *bucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Bucket id",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"org_id": schema.StringAttribute{
-				MarkdownDescription: "Bucket id",
+				MarkdownDescription: "Bucket organization id",
 				Required:            true,
 				Optional:            false,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Bucket description",
@@ -77,9 +236,14 @@ func (r *bucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 			},
 			"retention_rules": schema.ListNestedAttribute{
-				Required:            true,
-				Optional:            false,
-				MarkdownDescription: "Bucket retention rules",
+				MarkdownDescription: "Bucket retention rules. When omitted the provider `default_retention_rules` apply, or the server default retention when those are unset. " +
+					"This is the canonical form, used by imports and generated configuration.",
+				Required: false,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"every_seconds": schema.Int64Attribute{
@@ -95,20 +259,83 @@ func (r *bucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Bucket retention policy",
 				Required:            false,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"schema_type": schema.StringAttribute{
-				MarkdownDescription: "Bucket retention policy",
+				MarkdownDescription: "Bucket schema type, `implicit` or `explicit`",
 				Required:            false,
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Bucket creation date",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "API token used for this resource instead of the provider token, " +
+					"for example a token scoped to the bucket organization",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"updated_at": schema.StringAttribute{
 				MarkdownDescription: "Bucket update date",
 				Computed:            true,
 			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Delete all data in the bucket before destroying it. " +
+					"When false, the default, destroying a bucket that still contains data fails, " +
+					"so buckets drained by another process first are only deleted once empty.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"effective_labels": effectiveLabelsAttribute("Labels attached to the bucket, including the provider `default_labels`"),
+			"retain_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Leave the bucket and its data in place when the resource is destroyed, " +
+					"only removing it from the Terraform state. This also applies to replacements: " +
+					"the new bucket is created and the old one is left behind.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When a bucket with the same name already exists in the organization, " +
+					"update it to match the configuration and manage it instead of failing the create.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+			}),
+			"retention_rule": schema.ListNestedBlock{
+				MarkdownDescription: "Retention rule in block syntax, an alternative to `retention_rules` that cannot be combined with it. " +
+					"The rules are planned into `retention_rules`, which holds the rules stored on the server.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"every_seconds": schema.Int64Attribute{
+							Required: true,
+						},
+						"retention_type": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -118,21 +345,80 @@ func (r *bucketResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(influxdb2.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *influxdb2.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+// ValidateConfig rejects buckets setting retention rules in both the
+// attribute and the block syntax.
+func (r *bucketResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config bucketResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.RetentioRules.IsNull() && len(config.RetentionRule.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retention_rule"),
+			"Conflicting retention rules",
+			"Set retention rules either with the retention_rules attribute or with retention_rule blocks, not both.",
 		)
+	}
+}
+
+// ModifyPlan plans the retention_rule blocks into retention_rules, and
+// applies the provider default_retention_rules to buckets that configure
+// neither, so changing the default shows as a diff on every bucket relying
+// on it. It also plans effective_labels as unknown when
+// a provider default_labels entry is missing from the bucket, so the label is
+// attached again, and warns when the provider token cannot manage buckets.
+func (r *bucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_bucket", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var configured, blocks types.List
 
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("retention_rules"), &configured)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("retention_rule"), &blocks)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.client = client
+	switch {
+	case !configured.IsNull():
+	case blocks.IsUnknown():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("retention_rules"), types.ListUnknown(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}))...)
+	case len(blocks.Elements()) > 0:
+		rules, diags := types.ListValue(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}, blocks.Elements())
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("retention_rules"), rules)...)
+	case !r.providerData.defaultRetentionRules.IsNull():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("retention_rules"), r.providerData.defaultRetentionRules)...)
+	}
+
+	planEffectiveLabels(ctx, r.providerData, req, resp)
 }
 
 func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
 	var state bucketResourceModel
 
 	// Read Terraform plan state into the model
@@ -142,13 +428,13 @@ func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var retentionRules []domain.RetentionRule
+	ctx = withObjectFields(ctx, "influxdbv2_bucket", state.Name, state.Id)
 
-	for _, rule := range state.RetentioRules {
-		retentionRules = append(retentionRules, domain.RetentionRule{
-			EverySeconds: rule.EverySeconds.ValueInt64(),
-			Type:         (*domain.RetentionRuleType)(rule.RetentionType.ValueStringPointer()),
-		})
+	retentionRules, diags := expandRetentionRules(ctx, state.RetentioRules)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	var bucket domain.Bucket
@@ -156,12 +442,28 @@ func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest,
 	bucket.Description = state.Description.ValueStringPointer()
 	bucket.OrgID = state.OrgID.ValueStringPointer()
 	bucket.Rp = state.RP.ValueStringPointer()
+	bucket.SchemaType = (*domain.SchemaType)(state.ScehmaType.ValueStringPointer())
 	bucket.RetentionRules = retentionRules
 
-	newBucket, err := r.client.BucketsAPI().CreateBucket(context.Background(), &bucket)
+	client := r.providerData.clientFor(state.Token)
+
+	newBucket, err := createAfterDeletion(ctx, r.providerData, bucketDeletionKey(state.OrgID.ValueString(), state.Name.ValueString()), func() (*domain.Bucket, error) {
+		return client.BucketsAPI().CreateBucket(ctx, &bucket)
+	})
+
+	if isConflict(err) && state.AdoptExisting.ValueBool() {
+		newBucket, err = adoptBucket(ctx, client, bucket)
+
+		if err == nil {
+			resp.Diagnostics.AddWarning(
+				"Adopted existing bucket",
+				fmt.Sprintf("Bucket %s already existed in organization %s with ID %s and is now managed by this resource.", state.Name, state.OrgID, stringValueOrNull(newBucket.Id).ValueString()),
+			)
+		}
+	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, bucketErrorAttributes,
 			"Error creating bucket",
 			fmt.Sprintf("Error: %s", err),
 		)
@@ -169,24 +471,23 @@ func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var newRetentionRules []bucketRetentionRulesModel
+	if newBucket.Id != nil {
+		r.providerData.recordCreation(*newBucket.Id)
+		ctx = tflog.SetField(ctx, logFieldResourceID, *newBucket.Id)
+	}
 
-	for _, rule := range newBucket.RetentionRules {
-		newRetentionRules = append(newRetentionRules, bucketRetentionRulesModel{
-			EverySeconds:  types.Int64Value(rule.EverySeconds),
-			RetentionType: types.StringPointerValue((*string)(rule.Type)),
-		})
+	newBucket, err = r.applyDefaultLabels(ctx, client, newBucket)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling bucket",
+			fmt.Sprintf("Bucket %s was created but : %s", state.Name, err),
+		)
+
+		return
 	}
 
-	state.Id = types.StringPointerValue(newBucket.Id)
-	state.Name = types.StringValue(newBucket.Name)
-	state.Description = types.StringPointerValue(newBucket.Description)
-	state.OrgID = types.StringPointerValue(newBucket.OrgID)
-	state.RP = types.StringPointerValue(newBucket.Rp)
-	state.RetentioRules = newRetentionRules
-	state.ScehmaType = types.StringPointerValue((*string)(newBucket.SchemaType))
-	state.CreatedAt = types.StringValue(newBucket.CreatedAt.String())
-	state.UpdatedAt = types.StringValue(newBucket.UpdatedAt.String())
+	resp.Diagnostics.Append(bucketToModel(ctx, newBucket, &state)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -197,6 +498,8 @@ func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *bucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
 	var state bucketResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -205,10 +508,16 @@ func (r *bucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	bucket, err := r.client.BucketsAPI().FindBucketByID(context.Background(), state.Id.ValueString())
+	ctx = withObjectFields(ctx, "influxdbv2_bucket", state.Name, state.Id)
+
+	client := r.providerData.clientFor(state.Token)
+
+	bucket, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Bucket, error) {
+		return client.BucketsAPI().FindBucketByID(ctx, state.Id.ValueString())
+	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(ctx, &resp.Diagnostics,
 			"Error reading bucket",
 			fmt.Sprintf("Could not read bucket %s with ID %s : %s", state.Name, state.Id, err),
 		)
@@ -216,24 +525,8 @@ func (r *bucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	var actualRetentionRules []bucketRetentionRulesModel
-
-	for _, rule := range bucket.RetentionRules {
-		actualRetentionRules = append(actualRetentionRules, bucketRetentionRulesModel{
-			EverySeconds:  types.Int64Value(rule.EverySeconds),
-			RetentionType: types.StringPointerValue((*string)(rule.Type)),
-		})
-	}
+	resp.Diagnostics.Append(bucketToModel(ctx, bucket, &state)...)
 
-	state.Id = types.StringPointerValue(bucket.Id)
-	state.Name = types.StringValue(bucket.Name)
-	state.Description = types.StringPointerValue(bucket.Description)
-	state.OrgID = types.StringPointerValue(bucket.OrgID)
-	state.RP = types.StringPointerValue(bucket.Rp)
-	state.RetentioRules = actualRetentionRules
-	state.ScehmaType = types.StringPointerValue((*string)(bucket.SchemaType))
-	state.CreatedAt = types.StringValue(bucket.CreatedAt.String())
-	state.UpdatedAt = types.StringValue(bucket.UpdatedAt.String())
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -242,6 +535,8 @@ func (r *bucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
 	var plan bucketResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -249,6 +544,8 @@ func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = withObjectFields(ctx, "influxdbv2_bucket", plan.Name, plan.Id)
+
 	var state bucketResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -256,10 +553,14 @@ func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	bucket, err := r.client.BucketsAPI().FindBucketByID(context.Background(), state.Id.ValueString())
+	client := r.providerData.clientFor(plan.Token)
+
+	bucket, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Bucket, error) {
+		return client.BucketsAPI().FindBucketByID(ctx, state.Id.ValueString())
+	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(ctx, &resp.Diagnostics,
 			"Error reading bucket",
 			fmt.Sprintf("Could not read bucket %s with ID %s : %s", plan.Name, state.Id.ValueString(), err),
 		)
@@ -276,23 +577,21 @@ func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var retentionRules []domain.RetentionRule
+	retentionRules, diags := expandRetentionRules(ctx, plan.RetentioRules)
+	resp.Diagnostics.Append(diags...)
 
-	for _, rule := range state.RetentioRules {
-		retentionRules = append(retentionRules, domain.RetentionRule{
-			EverySeconds: rule.EverySeconds.ValueInt64(),
-			Type:         (*domain.RetentionRuleType)(rule.RetentionType.ValueStringPointer()),
-		})
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	bucket.Name = plan.Name.ValueString()
 	bucket.Description = plan.Description.ValueStringPointer()
 	bucket.RetentionRules = retentionRules
 
-	bucket, err = r.client.BucketsAPI().UpdateBucket(context.Background(), bucket)
+	bucket, err = client.BucketsAPI().UpdateBucket(ctx, bucket)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, bucketErrorAttributes,
 			"Error updating bucket",
 			fmt.Sprintf("Could not update bucket %s with ID %s : %s", plan.Name, plan.Id, err),
 		)
@@ -300,13 +599,18 @@ func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	plan.Id = types.StringPointerValue(bucket.Id)
+	bucket, err = r.applyDefaultLabels(ctx, client, bucket)
 
-	plan.Description = types.StringPointerValue(bucket.Description)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling bucket",
+			fmt.Sprintf("Bucket %s with ID %s was updated but : %s", plan.Name, plan.Id, err),
+		)
 
-	plan.CreatedAt = types.StringValue(bucket.CreatedAt.String())
+		return
+	}
 
-	plan.UpdatedAt = types.StringValue(bucket.UpdatedAt.String())
+	resp.Diagnostics.Append(bucketToModel(ctx, bucket, &plan)...)
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -316,6 +620,8 @@ func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *bucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
 	var state bucketResourceModel
 
 	// Read Terraform prior state data into the model
@@ -325,19 +631,96 @@ func (r *bucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.BucketsAPI().DeleteBucketWithID(context.Background(), state.Id.ValueString())
+	ctx = withObjectFields(ctx, "influxdbv2_bucket", state.Name, state.Id)
+
+	if state.RetainOnDelete.ValueBool() {
+		tflog.Warn(ctx, "retain_on_delete is set, leaving bucket in place", map[string]interface{}{
+			"id":   state.Id.ValueString(),
+			"name": state.Name.ValueString(),
+		})
+
+		resp.Diagnostics.AddWarning(
+			"Bucket retained",
+			fmt.Sprintf("Bucket %s with ID %s was removed from the Terraform state but left in place because retain_on_delete is set.", state.Name, state.Id),
+		)
+
+		return
+	}
+
+	client := r.providerData.clientFor(state.Token)
+
+	if state.ForceDestroy.ValueBool() {
+		err := purgeBucketData(ctx, client, state.OrgID.ValueString(), state.Id.ValueString())
+
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics,
+				"Error deleting bucket data",
+				fmt.Sprintf("Could not purge data from bucket %s with ID %s : %s", state.Name, state.Id, err),
+			)
+
+			return
+		}
+	} else {
+		hasData, err := bucketHasData(ctx, client, state.OrgID.ValueString(), state.Id.ValueString())
+
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics,
+				"Error deleting bucket",
+				fmt.Sprintf("Could not check whether bucket %s with ID %s contains data : %s", state.Name, state.Id, err),
+			)
+
+			return
+		}
+
+		if hasData {
+			resp.Diagnostics.AddError(
+				"Error deleting bucket",
+				fmt.Sprintf("Bucket %s with ID %s still contains data. Set force_destroy = true to purge its data and delete it.", state.Name, state.Id),
+			)
+
+			return
+		}
+	}
+
+	err := client.BucketsAPI().DeleteBucketWithID(ctx, state.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleteing bucket",
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting bucket",
 			fmt.Sprintf("Could not update bucket %s with ID %s : %s", state.Name, state.Id, err),
 		)
 
 		return
 	}
 
+	r.providerData.recordDeletion(bucketDeletionKey(state.OrgID.ValueString(), state.Name.ValueString()))
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, deletionTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = waitForDeletion(ctx, deleteTimeout, func(ctx context.Context) error {
+		_, err := client.BucketsAPI().FindBucketByID(ctx, state.Id.ValueString())
+
+		return err
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting bucket",
+			fmt.Sprintf("Bucket %s with ID %s was deleted but is still returned by the server : %s", state.Name, state.Id, err),
+		)
+	}
 }
 
 func (r *bucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_delete"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retention_rule"), types.ListValueMust(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}, nil))...)
 }
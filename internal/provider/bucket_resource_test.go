@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestBucketToModelNullHandling(t *testing.T) {
+	ctx := context.Background()
+	id := "0000000000000001"
+	orgID := "0000000000000002"
+	empty := ""
+
+	var model bucketResourceModel
+
+	diags := bucketToModel(ctx, &domain.Bucket{
+		Id:          &id,
+		Name:        "imported",
+		OrgID:       &orgID,
+		Description: &empty,
+	}, &model)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !model.Description.IsNull() || !model.RP.IsNull() || !model.ScehmaType.IsNull() {
+		t.Errorf("expected unset optional attributes to be null, got %s %s %s", model.Description, model.RP, model.ScehmaType)
+	}
+
+	if model.RetentioRules.IsNull() || len(model.RetentioRules.Elements()) != 0 {
+		t.Errorf("expected an empty known retention_rules list, got %s", model.RetentioRules)
+	}
+
+	if !model.CreatedAt.IsNull() {
+		t.Errorf("expected missing created_at to be null, got %s", model.CreatedAt)
+	}
+}
+
+func TestRetentionRulesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	expire := domain.RetentionRuleTypeExpire
+
+	list, diags := flattenRetentionRules(ctx, []domain.RetentionRule{{EverySeconds: 3600, Type: &expire}})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	rules, diags := expandRetentionRules(ctx, list)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(rules) != 1 || rules[0].EverySeconds != 3600 || *rules[0].Type != expire {
+		t.Errorf("unexpected rules after round trip: %+v", rules)
+	}
+}
+
+// bucketPlanFor returns the plan and config of model for the bucket schema.
+func bucketPlanFor(t *testing.T, model bucketResourceModel) (tfsdk.Plan, tfsdk.Config) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&bucketResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if model.Timeouts.IsNull() {
+		model.Timeouts = timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{"delete": types.StringType})}
+	}
+
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}, tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestBucketValidateConfigRetentionRuleConflict(t *testing.T) {
+	ctx := context.Background()
+	expire := domain.RetentionRuleTypeExpire
+
+	rules, _ := flattenRetentionRules(ctx, []domain.RetentionRule{{EverySeconds: 3600, Type: &expire}})
+
+	_, config := bucketPlanFor(t, bucketResourceModel{
+		Name:            types.StringValue("bucket"),
+		RetentioRules:   rules,
+		RetentionRule:   rules,
+		EffectiveLabels: types.SetNull(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+	})
+	resp := resource.ValidateConfigResponse{}
+
+	(&bucketResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error when both retention_rules and retention_rule are set")
+	}
+}
+
+func TestBucketModifyPlanAppliesDefaultRetentionRules(t *testing.T) {
+	ctx := context.Background()
+	expire := domain.RetentionRuleTypeExpire
+
+	defaults, _ := flattenRetentionRules(ctx, []domain.RetentionRule{{EverySeconds: 7776000, Type: &expire}})
+	explicit, _ := flattenRetentionRules(ctx, []domain.RetentionRule{{EverySeconds: 3600, Type: &expire}})
+
+	data := newProviderData("http://localhost:8086", "token", http.DefaultTransport)
+	data.defaultRetentionRules = defaults
+
+	r := &bucketResource{providerData: data}
+
+	unset := types.ListNull(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes})
+	noBlocks := types.ListValueMust(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}, nil)
+
+	for _, test := range []struct {
+		configured types.List
+		blocks     types.List
+		expected   types.List
+	}{
+		{configured: unset, blocks: noBlocks, expected: defaults},
+		{configured: explicit, blocks: noBlocks, expected: explicit},
+		{configured: unset, blocks: explicit, expected: explicit},
+	} {
+		plan, config := bucketPlanFor(t, bucketResourceModel{
+			Name:            types.StringValue("bucket"),
+			RetentioRules:   test.configured,
+			RetentionRule:   test.blocks,
+			EffectiveLabels: types.SetNull(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+		})
+		resp := resource.ModifyPlanResponse{Plan: plan}
+
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: config}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var planned types.List
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("retention_rules"), &planned)...)
+
+		if !planned.Equal(test.expected) {
+			t.Errorf("expected %s to be planned, got %s", test.expected, planned)
+		}
+	}
+}
+
+// newAdoptServer lists the given buckets, filtered by orgID and name like
+// the server does, and answers bucket updates with the updated bucket.
+func newAdoptServer(t *testing.T, buckets []domain.Bucket, updated *domain.Bucket) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			_ = json.NewDecoder(r.Body).Decode(updated)
+			updated.Id = &[]string{strings.TrimPrefix(r.URL.Path, "/api/v2/buckets/")}[0]
+			_ = json.NewEncoder(w).Encode(updated)
+
+			return
+		}
+
+		matches := []domain.Bucket{}
+
+		for _, bucket := range buckets {
+			if *bucket.OrgID == r.URL.Query().Get("orgID") && bucket.Name == r.URL.Query().Get("name") {
+				matches = append(matches, bucket)
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(domain.Buckets{Buckets: &matches})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAdoptBucket(t *testing.T) {
+	ctx := context.Background()
+	expire := domain.RetentionRuleTypeExpire
+	explicit := domain.SchemaTypeExplicit
+	description := "adopted"
+
+	ids := []string{"0000000000000001", "0000000000000002"}
+	orgIDs := []string{"000000000000000a", "000000000000000b"}
+
+	buckets := []domain.Bucket{
+		{Id: &ids[0], OrgID: &orgIDs[0], Name: "metrics"},
+		{Id: &ids[1], OrgID: &orgIDs[1], Name: "other org"},
+	}
+
+	planned := func(orgID string, name string) domain.Bucket {
+		return domain.Bucket{
+			OrgID:          &orgID,
+			Name:           name,
+			Description:    &description,
+			RetentionRules: []domain.RetentionRule{{EverySeconds: 3600, Type: &expire}},
+		}
+	}
+
+	var updated domain.Bucket
+
+	client := newInfluxClient(newAdoptServer(t, buckets, &updated).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	bucket, err := adoptBucket(ctx, client, planned(orgIDs[0], "metrics"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *bucket.Id != ids[0] || *updated.Description != description || updated.RetentionRules[0].EverySeconds != 3600 {
+		t.Errorf("expected the existing bucket to be updated to the plan, got %+v", updated)
+	}
+
+	if _, err := adoptBucket(ctx, client, planned(orgIDs[0], "other org")); err == nil {
+		t.Error("expected an error adopting a bucket of another organization")
+	}
+
+	withSchema := planned(orgIDs[0], "metrics")
+	withSchema.SchemaType = &explicit
+
+	if _, err := adoptBucket(ctx, client, withSchema); err == nil || !strings.Contains(err.Error(), "schema_type") {
+		t.Errorf("expected a schema_type error, got %v", err)
+	}
+}
+
+func TestBucketDeleteDataCheck(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		forceDestroy bool
+		populated    bool
+		purged       bool
+		deleted      bool
+	}{
+		{forceDestroy: false, populated: false, deleted: true},
+		{forceDestroy: false, populated: true},
+		{forceDestroy: true, populated: true, purged: true, deleted: true},
+	}
+
+	for _, test := range tests {
+		hasData, purged, deleted := test.populated, false, false
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/api/v2/query":
+				w.Header().Set("Content-Type", "text/csv")
+
+				if hasData {
+					_, _ = w.Write([]byte(testFluxRowCSV))
+				}
+			case r.URL.Path == "/api/v2/delete":
+				purged, hasData = true, false
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/api/v2/buckets/0000000000000002" && r.Method == http.MethodDelete:
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/api/v2/buckets/0000000000000002" && deleted:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":"not found","message":"bucket not found"}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+
+		plan, _ := bucketPlanFor(t, bucketResourceModel{
+			Id:              types.StringValue("0000000000000002"),
+			OrgID:           types.StringValue("0000000000000001"),
+			Name:            types.StringValue("archive"),
+			ForceDestroy:    types.BoolValue(test.forceDestroy),
+			RetentioRules:   types.ListNull(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}),
+			RetentionRule:   types.ListNull(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}),
+			EffectiveLabels: types.SetNull(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+		})
+		resp := resource.DeleteResponse{}
+
+		r := &bucketResource{providerData: newProviderData(server.URL, "token", http.DefaultTransport)}
+		r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+		server.Close()
+
+		if purged != test.purged || deleted != test.deleted || resp.Diagnostics.HasError() == test.deleted {
+			t.Errorf("force_destroy = %t on a bucket with data %t: expected purged %t and deleted %t, got %t and %t with %v",
+				test.forceDestroy, test.populated, test.purged, test.deleted, purged, deleted, resp.Diagnostics)
+		}
+	}
+}
+
+// TestAccBucketResourceImportGeneratedConfig imports a bucket created outside
+// of Terraform with an import block and the configuration
+// `terraform plan -generate-config-out` writes for it, which must plan no
+// change, during the import or afterwards.
+func TestAccBucketResourceImportGeneratedConfig(t *testing.T) {
+	if os.Getenv(resourcetest.EnvTfAcc) == "" {
+		t.Skipf("acceptance tests skipped unless %s is set", resourcetest.EnvTfAcc)
+	}
+
+	testAccPreCheck(t)
+
+	client, orgID := testAccClient(t)
+	name := acctest.RandomWithPrefix("tf-acc-import")
+	description, rp := "created outside of terraform", "autogen"
+	expire := domain.RetentionRuleTypeExpire
+
+	bucket, err := client.BucketsAPI().CreateBucket(context.Background(), &domain.Bucket{
+		OrgID:          &orgID,
+		Name:           name,
+		Description:    &description,
+		Rp:             &rp,
+		RetentionRules: domain.RetentionRules{{EverySeconds: 86400, Type: &expire}},
+	})
+
+	if err != nil {
+		t.Fatalf("could not create the bucket to import: %s", err)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_5_0)},
+		Steps: []resourcetest.TestStep{
+			{
+				// The configuration Terraform generates lists every
+				// configurable attribute in order, with null for sensitive
+				// values.
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+import {
+  to = influxdbv2_bucket.imported
+  id = %q
+}
+
+resource "influxdbv2_bucket" "imported" {
+  adopt_existing    = false
+  description       = %q
+  force_destroy     = false
+  name              = %q
+  org_id            = %q
+  retain_on_delete  = false
+  retention_rules = [{
+    every_seconds  = 86400
+    retention_type = "expire"
+  }]
+  rp          = %q
+  schema_type = "implicit"
+  token       = null # sensitive
+}
+`, *bucket.Id, description, name, orgID, rp),
+				ConfigPlanChecks: resourcetest.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("influxdbv2_bucket.imported", plancheck.ResourceActionNoop),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
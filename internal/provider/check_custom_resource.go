@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &checkCustomResource{}
+var _ resource.ResourceWithImportState = &checkCustomResource{}
+var _ resource.ResourceWithModifyPlan = &checkCustomResource{}
+
+func CheckCustomResource() resource.Resource {
+	return &checkCustomResource{}
+}
+
+// checkCustomResource defines the resource implementation.
+type checkCustomResource struct {
+	providerData *providerData
+}
+
+// checkCustomResourceModel describes the resource data model.
+type checkCustomResourceModel struct {
+	Id     types.String    `tfsdk:"id"`
+	OrgID  types.String    `tfsdk:"org_id"`
+	Name   types.String    `tfsdk:"name"`
+	Query  fluxScriptValue `tfsdk:"query"`
+	Status types.String    `tfsdk:"status"`
+
+	EffectiveLabels types.Set  `tfsdk:"effective_labels"`
+	ValidateOnPlan  types.Bool `tfsdk:"validate_on_plan"`
+}
+
+func (r *checkCustomResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_custom"
+}
+
+func (r *checkCustomResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom check, whose whole logic including the `option task` and `check` definitions is a Flux script.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Check id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Check name",
+				Required:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Flux script of the check. Differences in whitespace only are ignored.",
+				Required:            true,
+				CustomType:          fluxScriptType{},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Check status, `active` or `inactive`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("active"),
+			},
+			"effective_labels": effectiveLabelsAttribute("Labels attached to the check, including the provider `default_labels`"),
+			"validate_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Analyze the Flux script with the server at plan time. Defaults to the provider `validate_flux_on_plan`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *checkCustomResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+// ModifyPlan analyzes the query with the server when validate_on_plan is
+// enabled, and plans effective_labels as unknown when a provider
+// default_labels entry is missing from the check. It warns when the provider
+// token cannot manage checks.
+func (r *checkCustomResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_check_custom", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan checkCustomResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateFluxOnPlan(ctx, r.providerData, plan.ValidateOnPlan, path.Root("query"), plan.Query.StringValue, &resp.Diagnostics)
+	planEffectiveLabels(ctx, r.providerData, req, resp)
+}
+
+// checkCustomErrorAttributes lists the attributes API validation errors of
+// check requests can be attached to.
+var checkCustomErrorAttributes = map[string]bool{
+	"name":   true,
+	"query":  true,
+	"status": true,
+}
+
+// customCheckToModel maps the server representation of a check to the
+// resource model. The query is stored as returned, including its options.
+func customCheckToModel(ctx context.Context, check influxrest.Check, model *checkCustomResourceModel) diag.Diagnostics {
+	model.Id = types.StringValue(check.Id)
+	model.OrgID = types.StringValue(check.OrgID)
+	model.Name = types.StringValue(check.Name)
+	model.Query = newFluxScriptValue(check.Query.Text)
+	model.Status = types.StringValue(check.Status)
+
+	effectiveLabels, diags := flattenLabels(ctx, &check.Labels)
+	model.EffectiveLabels = effectiveLabels
+
+	return diags
+}
+
+// saveCustomCheck creates the check described by model, or replaces the
+// check with id when id is not empty.
+func saveCustomCheck(ctx context.Context, client influxdb2.Client, id string, model checkCustomResourceModel) (influxrest.Check, error) {
+	body := influxrest.CustomCheck{
+		Type:   "custom",
+		OrgID:  model.OrgID.ValueString(),
+		Name:   model.Name.ValueString(),
+		Status: model.Status.ValueString(),
+	}
+	body.Query.Text = model.Query.ValueString()
+
+	return influxrest.SaveCustomCheck(ctx, client, id, body)
+}
+
+// applyDefaultLabels attaches the provider default_labels missing from check
+// and returns the check as stored afterwards.
+func (r *checkCustomResource) applyDefaultLabels(ctx context.Context, check influxrest.Check) (influxrest.Check, error) {
+	if len(r.providerData.defaultLabels) == 0 {
+		return check, nil
+	}
+
+	client := r.providerData.client
+
+	err := attachDefaultLabels(ctx, client, check.OrgID, r.providerData.defaultLabels, &check.Labels, func(labelID string) error {
+		return addResourceLabel(ctx, client, "checks/"+url.PathEscape(check.Id), labelID)
+	})
+
+	if err != nil {
+		return check, err
+	}
+
+	return findAfterCreate(ctx, r.providerData, check.Id, func(ctx context.Context) (influxrest.Check, error) {
+		return influxrest.GetCheck(ctx, client, check.Id)
+	})
+}
+
+func (r *checkCustomResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state checkCustomResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_check_custom", state.Name, state.Id)
+
+	check, err := saveCustomCheck(ctx, r.providerData.client, "", state)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, checkCustomErrorAttributes,
+			"Error creating check",
+			fmt.Sprintf("Could not create check %s : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	check, err = r.applyDefaultLabels(ctx, check)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling check",
+			fmt.Sprintf("Check %s was created but : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(customCheckToModel(ctx, check, &state)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *checkCustomResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state checkCustomResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_check_custom", state.Name, state.Id)
+
+	check, err := influxrest.GetCheck(ctx, r.providerData.client, state.Id.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading check",
+			fmt.Sprintf("Could not read check %s with ID %s : %s", state.Name, state.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(customCheckToModel(ctx, check, &state)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *checkCustomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan checkCustomResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_check_custom", plan.Name, plan.Id)
+
+	check, err := saveCustomCheck(ctx, r.providerData.client, plan.Id.ValueString(), plan)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, checkCustomErrorAttributes,
+			"Error updating check",
+			fmt.Sprintf("Could not update check %s with ID %s : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	check, err = r.applyDefaultLabels(ctx, check)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling check",
+			fmt.Sprintf("Check %s with ID %s was updated but : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(customCheckToModel(ctx, check, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *checkCustomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state checkCustomResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_check_custom", state.Name, state.Id)
+
+	err := influxrest.DeleteCheck(ctx, r.providerData.client, state.Id.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting check",
+			fmt.Sprintf("Could not delete check %s with ID %s : %s", state.Name, state.Id, err),
+		)
+	}
+}
+
+func (r *checkCustomResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
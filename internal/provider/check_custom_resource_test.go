@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+func TestSaveCustomCheck(t *testing.T) {
+	query := "import \"influxdata/influxdb/monitor\"\n\noption task = {name: \"correlation\", every: 1m}\n\ncheck = {_check_id: \"0000000000000001\"}\n"
+
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var body influxrest.CustomCheck
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unexpected request body: %s", err)
+		}
+
+		if body.Type != "custom" || body.Query.Text != query || body.Status != "active" {
+			t.Errorf("unexpected request body %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "name": "correlation", "type": "custom", "status": "active", "query": {"text": ` + jsonString(query) + `}}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	model := checkCustomResourceModel{
+		OrgID:  types.StringValue("0000000000000002"),
+		Name:   types.StringValue("correlation"),
+		Query:  newFluxScriptValue(query),
+		Status: types.StringValue("active"),
+	}
+
+	for _, id := range []string{"", "0000000000000001"} {
+		check, err := saveCustomCheck(context.Background(), client, id, model)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var state checkCustomResourceModel
+		customCheckToModel(context.Background(), check, &state)
+
+		if state.Id.ValueString() != "0000000000000001" || state.Query.ValueString() != query {
+			t.Errorf("unexpected state %+v", state)
+		}
+	}
+
+	expected := []string{"POST /api/v2/checks", "PUT /api/v2/checks/0000000000000001"}
+
+	if len(requests) != len(expected) || requests[0] != expected[0] || requests[1] != expected[1] {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func jsonString(s string) string {
+	encoded, _ := json.Marshal(s)
+
+	return string(encoded)
+}
+
+func TestCheckCustomCreateAttachesDefaultLabels(t *testing.T) {
+	ctx := context.Background()
+
+	labels := `[]`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/labels":
+			_, _ = w.Write([]byte(`{"labels": [{"id": "0000000000000040", "name": "managed-by:terraform", "orgID": "0000000000000002"}]}`))
+		case "POST /api/v2/checks/0000000000000001/labels":
+			labels = `[{"id": "0000000000000040", "name": "managed-by:terraform"}]`
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"label": {"id": "0000000000000040", "name": "managed-by:terraform"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "name": "correlation", "type": "custom", "status": "active", "query": {"text": "check"}, "labels": ` + labels + `}`))
+		}
+	}))
+	defer server.Close()
+
+	data := newProviderData(server.URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	data.defaultLabels = []string{"managed-by:terraform"}
+
+	r := &checkCustomResource{providerData: data}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	diags := plan.Set(ctx, &checkCustomResourceModel{
+		Id:              types.StringUnknown(),
+		OrgID:           types.StringValue("0000000000000002"),
+		Name:            types.StringValue("correlation"),
+		Query:           newFluxScriptValue("check"),
+		Status:          types.StringValue("active"),
+		EffectiveLabels: types.SetUnknown(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+		ValidateOnPlan:  types.BoolNull(),
+	})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var effective types.Set
+	resp.State.GetAttribute(ctx, path.Root("effective_labels"), &effective)
+
+	if applied, _ := defaultLabelsApplied(ctx, effective, data.defaultLabels); !applied {
+		t.Errorf("expected the default label to be attached, got %s", effective)
+	}
+}
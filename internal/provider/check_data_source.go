@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &checkDataSource{}
+	_ datasource.DataSourceWithConfigure = &checkDataSource{}
+)
+
+func CheckDataSource() datasource.DataSource {
+	return &checkDataSource{}
+}
+
+type checkDataSource struct {
+	client influxdb2.Client
+}
+
+// checkDataSourceModel describes the data source data model.
+type checkDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	OrgID       types.String `tfsdk:"org_id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Status      types.String `tfsdk:"status"`
+	Every       types.String `tfsdk:"every"`
+	Query       types.String `tfsdk:"query"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *checkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check"
+}
+
+func (d *checkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Check looked up by `id` or by `org_id` and `name`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Check id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Check name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Check type, `threshold`, `deadman` or `custom`",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Check status, `active` or `inactive`",
+				Computed:            true,
+			},
+			"every": schema.StringAttribute{
+				MarkdownDescription: "Interval the check runs at",
+				Computed:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Flux query of the check",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Check description",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *checkDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// findCheck reads the check with id, or the only check of orgID called name
+// when id is empty.
+func findCheck(ctx context.Context, client influxdb2.Client, id string, orgID string, name string) (influxrest.Check, error) {
+	if id != "" {
+		return influxrest.GetCheck(ctx, client, id)
+	}
+
+	checks, err := influxrest.ListChecks(ctx, client, orgID)
+
+	if err != nil {
+		return influxrest.Check{}, err
+	}
+
+	return selectByName("check", orgID, name, checks,
+		func(check influxrest.Check) string { return check.Name },
+		func(check influxrest.Check) string { return check.Id },
+	)
+}
+
+func (d *checkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state checkDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	validateLookup(state.Id, state.OrgID, state.Name, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	check, err := findCheck(ctx, d.client, state.Id.ValueString(), state.OrgID.ValueString(), state.Name.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading check",
+			fmt.Sprintf("Could not read check : %s", err),
+		)
+
+		return
+	}
+
+	state.Id = types.StringValue(check.Id)
+	state.OrgID = types.StringValue(check.OrgID)
+	state.Name = types.StringValue(check.Name)
+	state.Type = types.StringValue(check.Type)
+	state.Status = types.StringValue(check.Status)
+	state.Every = stringValueOrNull(&check.Every)
+	state.Query = types.StringValue(check.Query.Text)
+	state.Description = stringValueOrNull(&check.Description)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
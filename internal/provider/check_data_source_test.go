@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v2/checks/0000000000000020":
+			_, _ = w.Write([]byte(`{"id":"0000000000000020","orgID":"0000000000000001","name":"cpu","type":"threshold","status":"active","every":"1m","query":{"text":"from(bucket: \"telegraf\")"}}`))
+		case "/api/v2/checks":
+			_, _ = w.Write([]byte(`{"checks":[
+				{"id":"0000000000000021","orgID":"0000000000000001","name":"heartbeat","type":"deadman","status":"inactive","every":"5m","query":{"text":""}},
+				{"id":"0000000000000022","orgID":"0000000000000001","name":"heartbeat","type":"custom","status":"active","every":"5m","query":{"text":""}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	check, err := findCheck(ctx, client, "0000000000000020", "", "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if check.Type != "threshold" || check.Every != "1m" || check.Query.Text != `from(bucket: "telegraf")` {
+		t.Errorf("unexpected check %+v", check)
+	}
+
+	_, err = findCheck(ctx, client, "", "0000000000000001", "heartbeat")
+
+	if err == nil || !strings.Contains(err.Error(), "0000000000000021, 0000000000000022") {
+		t.Errorf("expected an ambiguous name error listing the candidates, got %v", err)
+	}
+}
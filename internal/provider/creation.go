@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// readAfterCreateWindow is how long after a create not found responses
+	// for the new object are treated as replication lag and retried.
+	readAfterCreateWindow = 10 * time.Second
+
+	// readAfterCreateInterval is the first retry delay, doubled after every
+	// attempt.
+	readAfterCreateInterval = 250 * time.Millisecond
+)
+
+// recordCreation remembers when the object with id was created by this
+// provider instance.
+func (d *providerData) recordCreation(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.creations[id] = time.Now()
+}
+
+func (d *providerData) createdRecently(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	created, ok := d.creations[id]
+
+	return ok && time.Since(created) < readAfterCreateWindow
+}
+
+// findAfterCreate calls find and retries it with backoff while it fails with
+// a not found error shortly after the object with id was created. InfluxDB
+// Cloud may not serve an object for a few seconds after creating it.
+func findAfterCreate[T any](ctx context.Context, data *providerData, id string, find func(ctx context.Context) (T, error)) (T, error) {
+	interval := readAfterCreateInterval
+
+	for {
+		result, err := find(ctx)
+
+		if err == nil || !isNotFound(err) || !data.createdRecently(id) {
+			return result, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Object %s not found right after its creation, retrying in %s: %s", id, interval, err))
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+	}
+}
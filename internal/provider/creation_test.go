@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// newLaggingServer answers bucket lookups with 404 for the first misses
+// requests, as Cloud does right after a create, and counts the lookups.
+func newLaggingServer(t *testing.T, misses int, lookups *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*lookups++
+		w.Header().Set("Content-Type", "application/json")
+
+		if *lookups <= misses {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not found","message":"bucket not found"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"0000000000000002","name":"bucket"}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFindAfterCreateRetriesNotFound(t *testing.T) {
+	previous := readAfterCreateInterval
+	readAfterCreateInterval = time.Millisecond
+	t.Cleanup(func() { readAfterCreateInterval = previous })
+
+	tests := []struct {
+		name            string
+		created         bool
+		expectedLookups int
+		expectErr       bool
+	}{
+		{name: "recently created", created: true, expectedLookups: 3},
+		{name: "not created by the provider", created: false, expectedLookups: 1, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lookups := 0
+			server := newLaggingServer(t, 2, &lookups)
+
+			data := newProviderData(server.URL, "token", http.DefaultTransport)
+			defer data.client.Close()
+
+			if test.created {
+				data.recordCreation("0000000000000002")
+			}
+
+			bucket, err := findAfterCreate(context.Background(), data, "0000000000000002", func(ctx context.Context) (*domain.Bucket, error) {
+				return data.client.BucketsAPI().FindBucketByID(ctx, "0000000000000002")
+			})
+
+			if (err != nil) != test.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !test.expectErr && bucket.Name != "bucket" {
+				t.Errorf("unexpected bucket %+v", bucket)
+			}
+
+			if lookups != test.expectedLookups {
+				t.Errorf("expected %d lookups, got %d", test.expectedLookups, lookups)
+			}
+		})
+	}
+}
+
+func TestCreatedRecentlyExpires(t *testing.T) {
+	data := newProviderData("", "", http.DefaultTransport)
+	defer data.client.Close()
+
+	data.recordCreation("0000000000000002")
+
+	if !data.createdRecently("0000000000000002") {
+		t.Error("expected a fresh creation to be recent")
+	}
+
+	data.creations["0000000000000002"] = time.Now().Add(-readAfterCreateWindow)
+
+	if data.createdRecently("0000000000000002") {
+		t.Error("expected the creation to expire after readAfterCreateWindow")
+	}
+}
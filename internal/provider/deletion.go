@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// deletionPollInterval is how often a deleted object is looked up until
+	// the server stops returning it.
+	deletionPollInterval = time.Second
+
+	// deletionTimeout bounds the wait for a deletion to propagate when the
+	// resource does not set timeouts.delete.
+	deletionTimeout = 5 * time.Minute
+)
+
+// waitForDeletion calls find until it fails with a not found error. InfluxDB
+// Cloud keeps serving deleted objects for a short while, which breaks
+// replacing an object by another with the same name. The wait is bounded by
+// timeout.
+func waitForDeletion(ctx context.Context, timeout time.Duration, find func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := find(ctx)
+
+		if isNotFound(err) {
+			return nil
+		}
+
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deletion did not complete within %s", timeout)
+		case <-time.After(deletionPollInterval):
+		}
+	}
+}
+
+// bucketDeletionKey and organizationDeletionKey identify deleted objects by
+// the name a replacement would reuse.
+func bucketDeletionKey(orgID string, name string) string {
+	return fmt.Sprintf("bucket %s in organization %s", name, orgID)
+}
+
+func organizationDeletionKey(name string) string {
+	return fmt.Sprintf("organization %s", name)
+}
+
+// recordDeletion remembers that the object identified by key was deleted by
+// this provider instance, that is during the current apply.
+func (d *providerData) recordDeletion(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deletions[key] = true
+}
+
+func (d *providerData) deletedRecently(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.deletions[key]
+}
+
+// createAfterDeletion calls create and retries it once when it fails with a
+// name conflict right after an object with the same key was deleted, giving
+// the deletion time to reach the name index.
+func createAfterDeletion[T any](ctx context.Context, data *providerData, key string, create func() (T, error)) (T, error) {
+	result, err := create()
+
+	if err == nil || !isConflict(err) || !data.deletedRecently(key) {
+		return result, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Name conflict after deleting %s, retrying once: %s", key, err))
+
+	select {
+	case <-ctx.Done():
+		return result, err
+	case <-time.After(deletionPollInterval):
+	}
+
+	return create()
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// setDeletionPolling shortens deletion polling for the duration of a test.
+func setDeletionPolling(t *testing.T, interval time.Duration, timeout time.Duration) {
+	previousInterval, previousTimeout := deletionPollInterval, deletionTimeout
+	deletionPollInterval, deletionTimeout = interval, timeout
+
+	t.Cleanup(func() {
+		deletionPollInterval, deletionTimeout = previousInterval, previousTimeout
+	})
+}
+
+func TestWaitForDeletionPollsUntilNotFound(t *testing.T) {
+	setDeletionPolling(t, time.Millisecond, time.Second)
+
+	lookups := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		w.Header().Set("Content-Type", "application/json")
+
+		// The deleted bucket is still served by the first lookups.
+		if lookups < 3 {
+			_, _ = w.Write([]byte(`{"id":"0000000000000002","name":"bucket"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not found","message":"bucket not found"}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	err := waitForDeletion(context.Background(), deletionTimeout, func(ctx context.Context) error {
+		_, err := client.BucketsAPI().FindBucketByID(ctx, "0000000000000002")
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if lookups != 3 {
+		t.Errorf("expected 3 lookups, got %d", lookups)
+	}
+}
+
+func TestWaitForDeletionTimesOut(t *testing.T) {
+	setDeletionPolling(t, time.Millisecond, 20*time.Millisecond)
+
+	err := waitForDeletion(context.Background(), deletionTimeout, func(ctx context.Context) error {
+		return nil
+	})
+
+	if err == nil {
+		t.Error("expected a timeout error")
+	}
+}
+
+func TestCreateAfterDeletionRetriesNameConflict(t *testing.T) {
+	setDeletionPolling(t, time.Millisecond, time.Second)
+
+	creates := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creates++
+		w.Header().Set("Content-Type", "application/json")
+
+		if creates == 1 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"code":"conflict","message":"bucket with name bucket already exists"}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"0000000000000003","name":"bucket"}`))
+	}))
+	defer server.Close()
+
+	data := newProviderData(server.URL, "token", http.DefaultTransport)
+	key := bucketDeletionKey("0000000000000001", "bucket")
+	orgID := "0000000000000001"
+
+	create := func() (*domain.Bucket, error) {
+		return data.client.BucketsAPI().CreateBucket(context.Background(), &domain.Bucket{Name: "bucket", OrgID: &orgID})
+	}
+
+	if _, err := createAfterDeletion(context.Background(), data, key, create); err == nil {
+		t.Fatal("expected the conflict to be returned when nothing was deleted")
+	}
+
+	creates = 0
+	data.recordDeletion(key)
+
+	bucket, err := createAfterDeletion(context.Background(), data, key, create)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if bucket.Id == nil || *bucket.Id != "0000000000000003" || creates != 2 {
+		t.Errorf("expected the create to be retried once, got %d requests", creates)
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &downsamplingTaskResource{}
+	_ resource.ResourceWithImportState    = &downsamplingTaskResource{}
+	_ resource.ResourceWithModifyPlan     = &downsamplingTaskResource{}
+	_ resource.ResourceWithValidateConfig = &downsamplingTaskResource{}
+)
+
+// downsamplingAggregateFunctions lists the aggregate functions a downsampling
+// task may use.
+var downsamplingAggregateFunctions = []string{"mean", "max", "min", "last", "sum"}
+
+func DownsamplingTaskResource() resource.Resource {
+	return &downsamplingTaskResource{}
+}
+
+// downsamplingTaskResource defines the resource implementation.
+type downsamplingTaskResource struct {
+	providerData *providerData
+}
+
+// downsamplingTaskResourceModel describes the resource data model.
+type downsamplingTaskResourceModel struct {
+	Id                types.String    `tfsdk:"id"`
+	OrgID             types.String    `tfsdk:"org_id"`
+	Name              types.String    `tfsdk:"name"`
+	SourceBucket      types.String    `tfsdk:"source_bucket"`
+	DestinationBucket types.String    `tfsdk:"destination_bucket"`
+	Every             types.String    `tfsdk:"every"`
+	Window            types.String    `tfsdk:"window"`
+	AggregateFn       types.String    `tfsdk:"aggregate_fn"`
+	MeasurementFilter types.String    `tfsdk:"measurement_filter"`
+	Flux              fluxScriptValue `tfsdk:"flux"`
+	WaitForFirstRun   types.Bool      `tfsdk:"wait_for_first_run"`
+	FirstRunTimeout   types.String    `tfsdk:"first_run_timeout"`
+	EffectiveLabels   types.Set       `tfsdk:"effective_labels"`
+	ValidateOnPlan    types.Bool      `tfsdk:"validate_on_plan"`
+}
+
+func (r *downsamplingTaskResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_downsampling_task"
+}
+
+func (r *downsamplingTaskResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Task aggregating the data of a source bucket into a destination bucket with `aggregateWindow`. " +
+			"The Flux script is generated from the arguments and exposed in `flux`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Task id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Task name",
+				Required:            true,
+			},
+			"source_bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket read",
+				Required:            true,
+			},
+			"destination_bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket the aggregated data is written to",
+				Required:            true,
+			},
+			"every": schema.StringAttribute{
+				MarkdownDescription: "Interval the task runs at, a Flux duration such as `1h`. Each run reads the data of the last interval.",
+				Required:            true,
+			},
+			"window": schema.StringAttribute{
+				MarkdownDescription: "Aggregation window, a Flux duration such as `5m`",
+				Required:            true,
+			},
+			"aggregate_fn": schema.StringAttribute{
+				MarkdownDescription: "Aggregate function, `mean`, `max`, `min`, `last` or `sum`",
+				Required:            true,
+			},
+			"measurement_filter": schema.StringAttribute{
+				MarkdownDescription: "Only downsample this measurement",
+				Optional:            true,
+			},
+			"flux": schema.StringAttribute{
+				MarkdownDescription: "Generated Flux script of the task",
+				Computed:            true,
+				CustomType:          fluxScriptType{},
+			},
+			"wait_for_first_run": schema.BoolAttribute{
+				MarkdownDescription: "Run the task once right after creating it and fail the apply when the run fails. " +
+					"The task is kept and tainted, so the next apply recreates it.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"first_run_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the first run, a duration such as `5m`. Defaults to `" + defaultFirstRunTimeout + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultFirstRunTimeout),
+			},
+			"effective_labels": effectiveLabelsAttribute("Labels attached to the task, including the provider `default_labels`"),
+			"validate_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Analyze the Flux script with the server at plan time. Defaults to the provider `validate_flux_on_plan`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *downsamplingTaskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *downsamplingTaskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateFluxDuration("every", config.Every, &resp.Diagnostics)
+	validateFluxDuration("window", config.Window, &resp.Diagnostics)
+
+	validateFirstRunTimeout(config.FirstRunTimeout, &resp.Diagnostics)
+
+	if !config.AggregateFn.IsUnknown() && !config.AggregateFn.IsNull() {
+		if err := validateOneOf("aggregate_fn", config.AggregateFn.ValueString(), downsamplingAggregateFunctions); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("aggregate_fn"), "Invalid aggregate function", err.Error()+".")
+		}
+	}
+}
+
+// downsamplingFlux generates the Flux script of a downsampling task. Every
+// run aggregates the data written since the previous run.
+func downsamplingFlux(model downsamplingTaskResourceModel) string {
+	var script strings.Builder
+
+	fmt.Fprintf(&script, "option task = {name: %s, every: %s}\n\n", fluxString(model.Name.ValueString()), model.Every.ValueString())
+	fmt.Fprintf(&script, "from(bucket: %s)\n", fluxString(model.SourceBucket.ValueString()))
+	script.WriteString("  |> range(start: -task.every)\n")
+
+	if model.MeasurementFilter.ValueString() != "" {
+		fmt.Fprintf(&script, "  |> filter(fn: (r) => r._measurement == %s)\n", fluxString(model.MeasurementFilter.ValueString()))
+	}
+
+	fmt.Fprintf(&script, "  |> aggregateWindow(every: %s, fn: %s)\n", model.Window.ValueString(), model.AggregateFn.ValueString())
+	fmt.Fprintf(&script, "  |> to(bucket: %s)\n", fluxString(model.DestinationBucket.ValueString()))
+
+	return script.String()
+}
+
+// downsamplingInputsKnown reports whether every argument the script is
+// generated from is known.
+func downsamplingInputsKnown(model downsamplingTaskResourceModel) bool {
+	for _, value := range []types.String{model.Name, model.SourceBucket, model.DestinationBucket, model.Every, model.Window, model.AggregateFn, model.MeasurementFilter} {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ModifyPlan plans the generated script, so it can be reviewed before apply
+// and any argument change updates the task in place, and analyzes it with the
+// server when validate_on_plan is enabled. It also plans effective_labels as
+// unknown when a provider default_labels entry is missing from the task, and
+// warns when the provider token cannot manage tasks.
+func (r *downsamplingTaskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_downsampling_task", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flux := fluxScriptValue{StringValue: types.StringUnknown()}
+
+	if downsamplingInputsKnown(plan) {
+		flux = newFluxScriptValue(downsamplingFlux(plan))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("flux"), flux)...)
+
+	validateFluxOnPlan(ctx, r.providerData, plan.ValidateOnPlan, path.Root("flux"), flux.StringValue, &resp.Diagnostics)
+	planEffectiveLabels(ctx, r.providerData, req, resp)
+}
+
+// downsamplingTaskToModel maps the server representation of a task to the
+// resource model. The arguments the script is generated from are kept, a
+// script changed outside of Terraform shows as a diff on flux.
+func downsamplingTaskToModel(ctx context.Context, task *domain.Task, model *downsamplingTaskResourceModel) diag.Diagnostics {
+	model.Id = types.StringValue(task.Id)
+	model.OrgID = types.StringValue(task.OrgID)
+	model.Name = types.StringValue(task.Name)
+	model.Flux = newFluxScriptValue(task.Flux)
+
+	effectiveLabels, diags := flattenLabels(ctx, task.Labels)
+	model.EffectiveLabels = effectiveLabels
+
+	return diags
+}
+
+func (r *downsamplingTaskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_downsampling_task", state.Name, state.Id)
+
+	task, err := r.providerData.client.TasksAPI().CreateTaskByFlux(ctx, downsamplingFlux(state), state.OrgID.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error creating task",
+			fmt.Sprintf("Could not create downsampling task %s : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	r.providerData.recordCreation(task.Id)
+
+	task, err = applyTaskDefaultLabels(ctx, r.providerData, task)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling task",
+			fmt.Sprintf("Downsampling task %s was created but : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(downsamplingTaskToModel(ctx, task, &state)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || !state.WaitForFirstRun.ValueBool() {
+		return
+	}
+
+	timeout, _ := time.ParseDuration(state.FirstRunTimeout.ValueString())
+
+	if err := waitForFirstRun(ctx, r.providerData.client, task.Id, timeout); err != nil {
+		resp.Diagnostics.AddError(
+			"First run of task failed",
+			fmt.Sprintf("Downsampling task %s with ID %s was created but its first run failed: %s", state.Name, task.Id, err),
+		)
+	}
+}
+
+func (r *downsamplingTaskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_downsampling_task", state.Name, state.Id)
+
+	task, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Task, error) {
+		return r.providerData.client.TasksAPI().GetTaskByID(ctx, state.Id.ValueString())
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading task",
+			fmt.Sprintf("Could not read downsampling task %s with ID %s : %s", state.Name, state.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(downsamplingTaskToModel(ctx, task, &state)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *downsamplingTaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_downsampling_task", plan.Name, plan.Id)
+
+	every := plan.Every.ValueString()
+
+	task, err := r.providerData.client.TasksAPI().UpdateTask(ctx, &domain.Task{
+		Id:    plan.Id.ValueString(),
+		Name:  plan.Name.ValueString(),
+		Every: &every,
+		Flux:  downsamplingFlux(plan),
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error updating task",
+			fmt.Sprintf("Could not update downsampling task %s with ID %s : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	task, err = applyTaskDefaultLabels(ctx, r.providerData, task)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling task",
+			fmt.Sprintf("Downsampling task %s with ID %s was updated but : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(downsamplingTaskToModel(ctx, task, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *downsamplingTaskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state downsamplingTaskResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_downsampling_task", state.Name, state.Id)
+
+	err := r.providerData.client.TasksAPI().DeleteTaskWithID(ctx, state.Id.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting task",
+			fmt.Sprintf("Could not delete downsampling task %s with ID %s : %s", state.Name, state.Id, err),
+		)
+	}
+}
+
+func (r *downsamplingTaskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_first_run"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("first_run_timeout"), defaultFirstRunTimeout)...)
+}
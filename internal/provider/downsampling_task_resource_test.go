@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func downsamplingModel() downsamplingTaskResourceModel {
+	return downsamplingTaskResourceModel{
+		OrgID:             types.StringValue("0000000000000001"),
+		Name:              types.StringValue("cpu 5m"),
+		SourceBucket:      types.StringValue("raw"),
+		DestinationBucket: types.StringValue("downsampled"),
+		Every:             types.StringValue("1h"),
+		Window:            types.StringValue("5m"),
+		AggregateFn:       types.StringValue("mean"),
+		MeasurementFilter: types.StringValue("cpu"),
+		EffectiveLabels:   types.SetUnknown(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+	}
+}
+
+func downsamplingPlanFor(t *testing.T, model downsamplingTaskResourceModel) (tfsdk.Plan, tfsdk.Config) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&downsamplingTaskResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}, tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestDownsamplingFlux(t *testing.T) {
+	expected := `option task = {name: "cpu 5m", every: 1h}
+
+from(bucket: "raw")
+  |> range(start: -task.every)
+  |> filter(fn: (r) => r._measurement == "cpu")
+  |> aggregateWindow(every: 5m, fn: mean)
+  |> to(bucket: "downsampled")
+`
+
+	if got := downsamplingFlux(downsamplingModel()); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	model := downsamplingModel()
+	model.MeasurementFilter = types.StringNull()
+
+	expected = `option task = {name: "cpu 5m", every: 1h}
+
+from(bucket: "raw")
+  |> range(start: -task.every)
+  |> aggregateWindow(every: 5m, fn: mean)
+  |> to(bucket: "downsampled")
+`
+
+	if got := downsamplingFlux(model); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDownsamplingTaskModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name     string
+		model    downsamplingTaskResourceModel
+		expected fluxScriptValue
+	}{
+		{name: "known", model: downsamplingModel(), expected: newFluxScriptValue(downsamplingFlux(downsamplingModel()))},
+		{name: "unknown bucket", model: func() downsamplingTaskResourceModel {
+			model := downsamplingModel()
+			model.DestinationBucket = types.StringUnknown()
+
+			return model
+		}(), expected: fluxScriptValue{StringValue: types.StringUnknown()}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			plan, config := downsamplingPlanFor(t, test.model)
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			(&downsamplingTaskResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: config}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var planned fluxScriptValue
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("flux"), &planned)...)
+
+			if !planned.Equal(test.expected) {
+				t.Errorf("expected %s to be planned, got %s", test.expected, planned)
+			}
+		})
+	}
+}
+
+func TestDownsamplingTaskValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	model := downsamplingModel()
+	model.Every = types.StringValue("-1h")
+	model.Window = types.StringValue("5 minutes")
+	model.AggregateFn = types.StringValue("median")
+
+	_, config := downsamplingPlanFor(t, model)
+	resp := resource.ValidateConfigResponse{}
+
+	(&downsamplingTaskResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 3 {
+		t.Errorf("expected errors on every, window and aggregate_fn, got %v", resp.Diagnostics)
+	}
+
+	_, config = downsamplingPlanFor(t, downsamplingModel())
+	resp = resource.ValidateConfigResponse{}
+
+	(&downsamplingTaskResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
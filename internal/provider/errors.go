@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// requestIDHeaders lists the response headers InfluxDB OSS and Cloud use to
+// identify a request, in order of preference.
+var requestIDHeaders = []string{"X-Influxdb-Request-Id", "Trace-Id"}
+
+type requestIDRecorderKey struct{}
+
+// requestIDRecorder keeps the request id of the last response received with
+// the context it is attached to.
+type requestIDRecorder struct {
+	mu sync.Mutex
+	id string
+}
+
+// withRequestID returns a context that records the request id of every
+// InfluxDB response received while using it.
+func withRequestID(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(requestIDRecorderKey{}).(*requestIDRecorder); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestIDRecorderKey{}, &requestIDRecorder{})
+}
+
+// lastRequestID returns the request id of the last response received with ctx.
+func lastRequestID(ctx context.Context) string {
+	recorder, ok := ctx.Value(requestIDRecorderKey{}).(*requestIDRecorder)
+
+	if !ok {
+		return ""
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return recorder.id
+}
+
+// requestIDTransport stores the request id header of each response in the
+// recorder attached to the request context.
+type requestIDTransport struct {
+	next http.RoundTripper
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	if resp == nil {
+		return resp, err
+	}
+
+	recorder, ok := req.Context().Value(requestIDRecorderKey{}).(*requestIDRecorder)
+
+	if !ok {
+		return resp, err
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			recorder.mu.Lock()
+			recorder.id = id
+			recorder.mu.Unlock()
+
+			break
+		}
+	}
+
+	return resp, err
+}
+
+// addAPIError adds an error diagnostic for a failed InfluxDB API call,
+// including the request id InfluxData support asks for when available.
+func addAPIError(ctx context.Context, diags *diag.Diagnostics, summary string, detail string) {
+	if id := lastRequestID(ctx); id != "" {
+		detail = fmt.Sprintf("%s (request id: %s)", detail, id)
+	}
+
+	diags.AddError(summary, detail)
+}
+
+// apiErrorPaths maps fragments of InfluxDB validation messages to the
+// attribute they are about. The first matching pattern wins, so more specific
+// patterns come first. The server does not say which retention rule it
+// rejected, and buckets accept a single rule, so rules map to index 0.
+var apiErrorPaths = []struct {
+	pattern *regexp.Regexp
+	path    path.Path
+}{
+	{regexp.MustCompile(`(?i)retention (period|policy|rule)|everySeconds|expiration`), path.Root("retention_rules").AtListIndex(0).AtName("every_seconds")},
+	{regexp.MustCompile(`(?i)schema ?type`), path.Root("schema_type")},
+	{regexp.MustCompile(`(?i)description`), path.Root("description")},
+	{regexp.MustCompile(`(?i)(bucket|organization|org) (name|with name)|name (is|must|may)|names may`), path.Root("name")},
+	{regexp.MustCompile(`(?i)orgID|organization id`), path.Root("org_id")},
+}
+
+// apiErrorPath returns the attribute an API validation error refers to.
+func apiErrorPath(err error) (path.Path, bool) {
+	for _, candidate := range apiErrorPaths {
+		if candidate.pattern.MatchString(err.Error()) {
+			return candidate.path, true
+		}
+	}
+
+	return path.Empty(), false
+}
+
+// addAttributeAPIError behaves like addAPIError, but attaches the error to
+// the offending attribute of attributes when the API message names one.
+func addAttributeAPIError(ctx context.Context, diags *diag.Diagnostics, err error, attributes map[string]bool, summary string, detail string) {
+	attributePath, ok := apiErrorPath(err)
+
+	if !ok || !attributes[attributePath.Steps()[0].String()] {
+		addAPIError(ctx, diags, summary, detail)
+
+		return
+	}
+
+	if id := lastRequestID(ctx); id != "" {
+		detail = fmt.Sprintf("%s (request id: %s)", detail, id)
+	}
+
+	diags.AddAttributeError(attributePath, summary, detail)
+}
+
+// isNotFound reports whether err is a 404 response.
+func isNotFound(err error) bool {
+	var apiError *influxrest.Error
+
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusNotFound
+	}
+
+	return clientErrorCode(err) == domain.ErrorCodeNotFound
+}
+
+// isConflict reports whether err is the answer to creating an object whose
+// name is already taken. OSS answers 422 and Cloud 409, both with the
+// conflict code.
+func isConflict(err error) bool {
+	var apiError *influxrest.Error
+
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusConflict || apiError.Code == string(domain.ErrorCodeConflict)
+	}
+
+	return clientErrorCode(err) == domain.ErrorCodeConflict
+}
+
+// clientErrorCode returns the code of an error returned by the generated
+// client, which drops the status code and formats errors as "code: message".
+func clientErrorCode(err error) domain.ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	code, _, found := strings.Cut(err.Error(), ": ")
+
+	if !found {
+		return ""
+	}
+
+	return domain.ErrorCode(code)
+}
+
+// addCloudAPIError reports the standard InfluxDB Cloud requirement error
+// when a Cloud only endpoint is missing, and behaves like addAPIError for
+// any other failure.
+func addCloudAPIError(ctx context.Context, diags *diag.Diagnostics, err error, feature string, summary string, detail string) {
+	if isNotFound(err) {
+		addAPIError(ctx, diags,
+			"InfluxDB Cloud required",
+			fmt.Sprintf("%s requires InfluxDB Cloud, the configured server does not implement it.", feature),
+		)
+
+		return
+	}
+
+	addAPIError(ctx, diags, summary, detail)
+}
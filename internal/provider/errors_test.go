@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAddAPIErrorIncludesRequestID(t *testing.T) {
+	for _, header := range requestIDHeaders {
+		t.Run(header, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(header, "req-0123456789")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":"not found","message":"bucket not found"}`))
+			}))
+			defer server.Close()
+
+			ctx := withRequestID(context.Background())
+			client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+			defer client.Close()
+
+			_, err := client.BucketsAPI().FindBucketByID(ctx, "0000000000000001")
+
+			if err == nil {
+				t.Fatal("expected an error from the fake server")
+			}
+
+			var diags diag.Diagnostics
+			addAPIError(ctx, &diags, "Error reading bucket", err.Error())
+
+			if !strings.Contains(diags[0].Detail(), "request id: req-0123456789") {
+				t.Errorf("expected request id in diagnostic, got %q", diags[0].Detail())
+			}
+		})
+	}
+}
+
+func TestAddAPIErrorWithoutRequestID(t *testing.T) {
+	var diags diag.Diagnostics
+	addAPIError(context.Background(), &diags, "Error reading bucket", "boom")
+
+	if diags[0].Detail() != "boom" {
+		t.Errorf("expected detail to be unchanged, got %q", diags[0].Detail())
+	}
+}
+
+func TestAPIErrorPath(t *testing.T) {
+	for _, test := range []struct {
+		message string
+		path    path.Path
+		found   bool
+	}{
+		{"unprocessable entity: retention policy duration must be at least 1h0m0s", path.Root("retention_rules").AtListIndex(0).AtName("every_seconds"), true},
+		{"invalid: expected retention period to be at least one hour", path.Root("retention_rules").AtListIndex(0).AtName("every_seconds"), true},
+		{"invalid: bucket name \"_monitoring\" is invalid. Buckets may not start with underscore", path.Root("name"), true},
+		{"conflict: bucket with name telegraf already exists", path.Root("name"), true},
+		{"conflict: organization with name team already exists", path.Root("name"), true},
+		{"invalid: description must be at most 1000 characters", path.Root("description"), true},
+		{"invalid: unknown schemaType \"strict\"", path.Root("schema_type"), true},
+		{"invalid: orgID is invalid", path.Root("org_id"), true},
+		{"internal error: unexpected failure", path.Empty(), false},
+	} {
+		got, found := apiErrorPath(errors.New(test.message))
+
+		if found != test.found || !got.Equal(test.path) {
+			t.Errorf("%q: expected %s %t, got %s %t", test.message, test.path, test.found, got, found)
+		}
+	}
+}
+
+func TestAddAttributeAPIErrorFallsBack(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	addAttributeAPIError(ctx, &diags, errors.New("invalid: unknown schemaType"), map[string]bool{"name": true}, "Error creating organization", "detail")
+
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+		t.Error("expected a resource level error for an attribute the resource does not have")
+	}
+
+	diags = nil
+	addAttributeAPIError(ctx, &diags, errors.New("conflict: organization with name team already exists"), map[string]bool{"name": true}, "Error creating organization", "detail")
+
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+
+	if !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Errorf("expected an error attached to name, got %v", diags)
+	}
+}
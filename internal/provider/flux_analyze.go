@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// analyzeFlux asks the server to analyze script and returns the problems it
+// found, formatted with their position.
+func analyzeFlux(ctx context.Context, client influxdb2.Client, script string) ([]string, error) {
+	payload, err := influxrest.Do(ctx, client, influxrest.Request{
+		Method: http.MethodPost,
+		Path:   "query/analyze",
+		Body:   map[string]string{"query": script, "type": "flux"},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response domain.AnalyzeQueryResponse
+
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Errors == nil {
+		return nil, nil
+	}
+
+	var problems []string
+
+	for _, problem := range *response.Errors {
+		message := stringValueOrNull(problem.Message).ValueString()
+
+		if problem.Line != nil && problem.Column != nil {
+			message = fmt.Sprintf("line %d, column %d: %s", *problem.Line, *problem.Column, message)
+		}
+
+		problems = append(problems, message)
+	}
+
+	return problems, nil
+}
+
+// validateFluxOnPlan reports the problems the server finds in script as
+// errors on attribute, when enabled is true or null and the provider sets
+// validate_flux_on_plan. Nothing is checked while the script or the provider
+// configuration is unknown, and a failing analyze call only logs.
+func validateFluxOnPlan(ctx context.Context, data *providerData, enabled types.Bool, attribute path.Path, script types.String, diags *diag.Diagnostics) {
+	if data == nil || script.IsUnknown() || script.IsNull() {
+		return
+	}
+
+	if !enabled.ValueBool() && (!enabled.IsNull() || !data.validateFluxOnPlan) {
+		return
+	}
+
+	problems, err := analyzeFlux(withRequestID(ctx), data.client, script.ValueString())
+
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping the Flux analysis: %s", err))
+
+		return
+	}
+
+	for _, problem := range problems {
+		diags.AddAttributeError(attribute, "Invalid Flux script", problem)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newFluxAnalyzeServer reports one error for every script and counts the
+// analyze calls.
+func newFluxAnalyzeServer(t *testing.T, calls *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/query/analyze" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		*calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors": [{"line": 3, "column": 6, "character": 40, "message": "undefined identifier fromm"}]}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAnalyzeFlux(t *testing.T) {
+	calls := 0
+
+	client := newInfluxClient(newFluxAnalyzeServer(t, &calls).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	problems, err := analyzeFlux(context.Background(), client, `fromm(bucket: "raw")`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(problems) != 1 || problems[0] != "line 3, column 6: undefined identifier fromm" {
+		t.Errorf("unexpected problems %q", problems)
+	}
+}
+
+func TestValidateFluxOnPlan(t *testing.T) {
+	calls := 0
+
+	data := newProviderData(newFluxAnalyzeServer(t, &calls).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	script := types.StringValue(`fromm(bucket: "raw")`)
+
+	for _, test := range []struct {
+		name            string
+		providerDefault bool
+		enabled         types.Bool
+		script          types.String
+		expectErr       bool
+	}{
+		{name: "disabled", enabled: types.BoolNull(), script: script},
+		{name: "enabled on the resource", enabled: types.BoolValue(true), script: script, expectErr: true},
+		{name: "provider default", providerDefault: true, enabled: types.BoolNull(), script: script, expectErr: true},
+		{name: "disabled on the resource", providerDefault: true, enabled: types.BoolValue(false), script: script},
+		{name: "unknown script", enabled: types.BoolValue(true), script: types.StringUnknown()},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			calls = 0
+			data.validateFluxOnPlan = test.providerDefault
+
+			var diags diag.Diagnostics
+
+			validateFluxOnPlan(context.Background(), data, test.enabled, path.Root("query"), test.script, &diags)
+
+			if diags.HasError() != test.expectErr || (calls > 0) != test.expectErr {
+				t.Errorf("expected error %t, got %d calls and %v", test.expectErr, calls, diags)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+
+	validateFluxOnPlan(context.Background(), nil, types.BoolValue(true), path.Root("query"), script, &diags)
+
+	if diags.HasError() {
+		t.Errorf("expected no check without a configured provider, got %v", diags)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = fluxDurationType{}
+	_ basetypes.StringValuableWithSemanticEquals = fluxDurationValue{}
+)
+
+// positiveFluxDurationPattern matches Flux duration literals such as 1h30m.
+var positiveFluxDurationPattern = regexp.MustCompile(`^([0-9]+(ns|us|µs|ms|s|m|h|d|w|mo|y))+$`)
+
+// fluxDurationUnitPattern matches one magnitude and unit of a Flux duration.
+var fluxDurationUnitPattern = regexp.MustCompile(`([0-9]+)(mo|ns|us|µs|ms|s|m|h|d|w|y)`)
+
+// fluxDurationUnits maps the units of Flux durations with a fixed length to
+// that length.
+var fluxDurationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// fluxDurationType is a string holding a Flux duration. The server returns
+// durations in its own format, such as 1h0m0s for 1h, so values of the same
+// length are semantically equal.
+type fluxDurationType struct {
+	basetypes.StringType
+}
+
+func (t fluxDurationType) Equal(o attr.Type) bool {
+	other, ok := o.(fluxDurationType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t fluxDurationType) String() string {
+	return "fluxDurationType"
+}
+
+func (t fluxDurationType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return fluxDurationValue{StringValue: in}, nil
+}
+
+func (t fluxDurationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return fluxDurationValue{StringValue: stringValue}, nil
+}
+
+func (t fluxDurationType) ValueType(_ context.Context) attr.Value {
+	return fluxDurationValue{}
+}
+
+// fluxDurationValue is a value of fluxDurationType.
+type fluxDurationValue struct {
+	basetypes.StringValue
+}
+
+func (v fluxDurationValue) Equal(o attr.Value) bool {
+	other, ok := o.(fluxDurationValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v fluxDurationValue) Type(_ context.Context) attr.Type {
+	return fluxDurationType{}
+}
+
+func (v fluxDurationValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(fluxDurationValue)
+
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	months, length, err := parseFluxDuration(v.ValueString())
+	newMonths, newLength, newErr := parseFluxDuration(newValue.ValueString())
+
+	if err != nil || newErr != nil {
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return months == newMonths && length == newLength, diags
+}
+
+// parseFluxDuration returns the months and the fixed length of a positive
+// Flux duration. Months and years have no fixed length, a year counts as 12
+// months.
+func parseFluxDuration(value string) (int, time.Duration, error) {
+	if !positiveFluxDurationPattern.MatchString(value) {
+		return 0, 0, fmt.Errorf("%q is not a positive Flux duration", value)
+	}
+
+	months, length := 0, time.Duration(0)
+
+	for _, match := range fluxDurationUnitPattern.FindAllStringSubmatch(value, -1) {
+		magnitude, err := strconv.Atoi(match[1])
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("%q is not a positive Flux duration: %w", value, err)
+		}
+
+		switch match[2] {
+		case "mo":
+			months += magnitude
+		case "y":
+			months += 12 * magnitude
+		default:
+			length += time.Duration(magnitude) * fluxDurationUnits[match[2]]
+		}
+	}
+
+	return months, length, nil
+}
+
+// validateFluxDuration checks that the attribute holds a positive Flux
+// duration.
+func validateFluxDuration(attribute string, value types.String, diags *diag.Diagnostics) {
+	if value.IsUnknown() || value.IsNull() || positiveFluxDurationPattern.MatchString(value.ValueString()) {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root(attribute),
+		"Invalid duration",
+		fmt.Sprintf("%s must be a positive Flux duration, integers each followed by one of the units ns, us, ms, s, m, h, d, w, mo or y, such as 1h or 1h30m, got %q.",
+			attribute, value.ValueString()),
+	)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFluxDurationSemanticEquals(t *testing.T) {
+	tests := []struct {
+		prior    string
+		new      string
+		expected bool
+	}{
+		{prior: "1h", new: "1h", expected: true},
+		{prior: "1h", new: "1h0m0s", expected: true},
+		{prior: "90m", new: "1h30m", expected: true},
+		{prior: "1d", new: "24h0m0s", expected: true},
+		{prior: "1w", new: "7d", expected: true},
+		{prior: "1y", new: "12mo", expected: true},
+		{prior: "1mo", new: "30d", expected: false},
+		{prior: "1h", new: "2h", expected: false},
+		{prior: "10ms", new: "10m", expected: false},
+		{prior: "90 minutes", new: "1h30m", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.prior+"_"+test.new, func(t *testing.T) {
+			prior := fluxDurationValue{StringValue: types.StringValue(test.prior)}
+			equal, diags := prior.StringSemanticEquals(context.Background(), fluxDurationValue{StringValue: types.StringValue(test.new)})
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != test.expected {
+				t.Errorf("expected semantic equality %t of %q and %q", test.expected, test.prior, test.new)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// fluxQueryTimeout bounds the schema queries run by data sources.
+var fluxQueryTimeout = 2 * time.Minute
+
+var (
+	fluxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`)
+
+	fluxDurationPattern = regexp.MustCompile(`^-?([0-9]+(ns|us|µs|ms|s|m|h|d|w|mo|y))+$`)
+)
+
+// fluxString quotes s as a Flux string literal.
+func fluxString(s string) string {
+	return `"` + fluxStringEscaper.Replace(s) + `"`
+}
+
+// fluxTime validates a range bound given as a duration relative to now, such
+// as -30d, or as an RFC3339 timestamp, and returns it as a Flux literal.
+func fluxTime(value string) (string, error) {
+	if fluxDurationPattern.MatchString(value) {
+		return value, nil
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("%q is neither a Flux duration such as -30d nor an RFC3339 timestamp", value)
+}
+
+// schemaQuery returns a query calling the function of the Flux schema
+// package with arguments.
+func schemaQuery(function string, arguments ...string) string {
+	return fmt.Sprintf("import \"influxdata/influxdb/schema\"\n\nschema.%s(%s)", function, strings.Join(arguments, ", "))
+}
+
+// queryValues runs query for org and returns the _value column of every
+// record. A query returning no tables returns no values.
+func queryValues(ctx context.Context, client influxdb2.Client, org string, query string) ([]interface{}, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, fluxQueryTimeout)
+	defer cancel()
+
+	// Report an expired query timeout instead of the cancellation it causes.
+	queryErr := func(err error) error {
+		if err != nil && ctx.Err() == nil && errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("the query did not complete within %s", fluxQueryTimeout)
+		}
+
+		return err
+	}
+
+	result, err := client.QueryAPI(org).Query(queryCtx, query)
+
+	if err != nil {
+		return nil, queryErr(err)
+	}
+
+	defer result.Close()
+
+	var values []interface{}
+
+	for result.Next() {
+		values = append(values, result.Record().Value())
+	}
+
+	return values, queryErr(result.Err())
+}
+
+// queryStrings runs query for org and returns the distinct string values of
+// its _value column.
+func queryStrings(ctx context.Context, client influxdb2.Client, org string, query string) ([]string, error) {
+	values, err := queryValues(ctx, client, org, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	strs := []string{}
+
+	for _, value := range values {
+		s, ok := value.(string)
+
+		if !ok || seen[s] {
+			continue
+		}
+
+		seen[s] = true
+		strs = append(strs, s)
+	}
+
+	return strs, nil
+}
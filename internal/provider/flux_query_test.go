@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testFluxStringsCSV is an annotated CSV query response with string values,
+// as returned by the schema functions.
+const testFluxStringsCSV = `#datatype,string,long,string
+#group,false,false,false
+#default,_result,,
+,result,table,_value
+,,0,cpu
+,,0,mem
+,,0,cpu
+
+`
+
+// newFluxQueryServer answers every query with status and body.
+func newFluxQueryServer(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/query" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if status != http.StatusOK {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/csv")
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFluxString(t *testing.T) {
+	tests := map[string]string{
+		`metrics`:       `"metrics"`,
+		`a "quoted" b`:  `"a \"quoted\" b"`,
+		`C:\data`:       `"C:\\data"`,
+		`${injected}`:   `"\${injected}"`,
+		`team's bucket`: `"team's bucket"`,
+	}
+
+	for input, expected := range tests {
+		if got := fluxString(input); got != expected {
+			t.Errorf("fluxString(%q): expected %s, got %s", input, expected, got)
+		}
+	}
+}
+
+func TestFluxTime(t *testing.T) {
+	for _, valid := range []string{"-30d", "-1h30m", "-1mo", "2024-01-01T00:00:00Z", "2024-01-01T00:00:00.5+02:00"} {
+		if got, err := fluxTime(valid); err != nil || got != valid {
+			t.Errorf("expected %q to be accepted, got %q %v", valid, got, err)
+		}
+	}
+
+	for _, invalid := range []string{"30 days", "-30d) |> drop(", "yesterday", ""} {
+		if _, err := fluxTime(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestQueryStrings(t *testing.T) {
+	client := newInfluxClient(newFluxQueryServer(t, http.StatusOK, testFluxStringsCSV).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	values, err := queryStrings(context.Background(), client, "org", `schema.measurements(bucket: "b")`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(values) != 2 || values[0] != "cpu" || values[1] != "mem" {
+		t.Errorf("expected the distinct values, got %v", values)
+	}
+}
+
+func TestQueryStringsEmpty(t *testing.T) {
+	client := newInfluxClient(newFluxQueryServer(t, http.StatusOK, "").URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	values, err := queryStrings(context.Background(), client, "org", `schema.measurements(bucket: "b")`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if values == nil || len(values) != 0 {
+		t.Errorf("expected an empty list, got %#v", values)
+	}
+}
+
+func TestQueryStringsFluxError(t *testing.T) {
+	body := `{"code":"invalid","message":"error calling function \"measurements\": bucket \"missing\" not found"}`
+	client := newInfluxClient(newFluxQueryServer(t, http.StatusBadRequest, body).URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	_, err := queryStrings(context.Background(), client, "org", `schema.measurements(bucket: "missing")`)
+
+	if err == nil || !strings.Contains(err.Error(), `bucket "missing" not found`) {
+		t.Errorf("expected the Flux error, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = fluxScriptType{}
+	_ basetypes.StringValuableWithSemanticEquals = fluxScriptValue{}
+)
+
+// fluxScriptType is a string holding a Flux script. The server reformats
+// stored scripts, so values differing only in whitespace are semantically
+// equal and do not cause a diff.
+type fluxScriptType struct {
+	basetypes.StringType
+}
+
+func (t fluxScriptType) Equal(o attr.Type) bool {
+	other, ok := o.(fluxScriptType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t fluxScriptType) String() string {
+	return "fluxScriptType"
+}
+
+func (t fluxScriptType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return fluxScriptValue{StringValue: in}, nil
+}
+
+func (t fluxScriptType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return fluxScriptValue{StringValue: stringValue}, nil
+}
+
+func (t fluxScriptType) ValueType(_ context.Context) attr.Value {
+	return fluxScriptValue{}
+}
+
+// fluxScriptValue is a value of fluxScriptType.
+type fluxScriptValue struct {
+	basetypes.StringValue
+}
+
+func newFluxScriptValue(script string) fluxScriptValue {
+	return fluxScriptValue{StringValue: basetypes.NewStringValue(script)}
+}
+
+func (v fluxScriptValue) Equal(o attr.Value) bool {
+	other, ok := o.(fluxScriptValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v fluxScriptValue) Type(_ context.Context) attr.Type {
+	return fluxScriptType{}
+}
+
+func (v fluxScriptValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(fluxScriptValue)
+
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return normalizeFlux(v.ValueString()) == normalizeFlux(newValue.ValueString()), diags
+}
+
+// normalizeFlux collapses every run of whitespace outside string literals and
+// comments to a single space, so scripts that only differ in indentation,
+// blank lines or trailing newlines normalize to the same text.
+func normalizeFlux(script string) string {
+	var out strings.Builder
+
+	inString, inComment, escaped, pendingSpace, lineStart := false, false, false, false, true
+	runes := []rune(script)
+
+	for i, r := range runes {
+		switch {
+		case inString:
+			out.WriteRune(r)
+
+			if escaped {
+				escaped = false
+			} else if r == '\\' {
+				escaped = true
+			} else if r == '"' {
+				inString = false
+			}
+		case inComment:
+			if r == '\n' {
+				inComment = false
+				lineStart = true
+				out.WriteRune('\n')
+
+				continue
+			}
+
+			out.WriteRune(r)
+		case unicode.IsSpace(r):
+			pendingSpace = true
+		default:
+			if pendingSpace && !lineStart {
+				out.WriteRune(' ')
+			}
+
+			pendingSpace, lineStart = false, false
+
+			if r == '"' {
+				inString = true
+			} else if r == '/' && i+1 < len(runes) && runes[i+1] == '/' {
+				inComment = true
+			}
+
+			out.WriteRune(r)
+		}
+	}
+
+	return strings.TrimRightFunc(out.String(), unicode.IsSpace)
+}
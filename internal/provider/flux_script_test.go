@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFluxScriptSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "identical",
+			prior:    "from(bucket: \"a\")",
+			new:      "from(bucket: \"a\")",
+			expected: true,
+		},
+		{
+			name:     "indentation and trailing newline",
+			prior:    "from(bucket: \"a\")\n  |> range(start: -1h)",
+			new:      "from(bucket: \"a\")\n\t|> range(start: -1h)\n\n",
+			expected: true,
+		},
+		{
+			name:     "blank lines and CRLF",
+			prior:    "option task = {name: \"t\", every: 1h}\n\nfrom(bucket: \"a\")",
+			new:      "option task = {name: \"t\", every: 1h}\r\nfrom(bucket: \"a\")\r\n",
+			expected: true,
+		},
+		{
+			name:     "whitespace inside a string",
+			prior:    "from(bucket: \"a b\")",
+			new:      "from(bucket: \"a  b\")",
+			expected: false,
+		},
+		{
+			name:     "escaped quote inside a string",
+			prior:    "x = \"a\\\"  b\"",
+			new:      "x = \"a\\\" b\"",
+			expected: false,
+		},
+		{
+			name:     "comment line break",
+			prior:    "// note\nx = 1",
+			new:      "// note x = 1",
+			expected: false,
+		},
+		{
+			name:     "different script",
+			prior:    "from(bucket: \"a\")",
+			new:      "from(bucket: \"b\")",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			equal, diags := newFluxScriptValue(test.prior).StringSemanticEquals(context.Background(), newFluxScriptValue(test.new))
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != test.expected {
+				t.Errorf("expected semantic equality %t, normalized %q and %q", test.expected, normalizeFlux(test.prior), normalizeFlux(test.new))
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// GenerateSettings holds the connection settings of Generate, which mirror
+// the provider configuration attributes of the same name.
+type GenerateSettings struct {
+	Host            string
+	Token           string
+	Org             string
+	AuthScheme      string
+	TLSMinVersion   string
+	TLSCipherSuites []string
+}
+
+// Generate writes import blocks and matching resource configurations for the
+// organization called settings.Org, or the only organization visible to the
+// token when it is empty, and for every resource of it the provider can
+// import. System buckets, whose names start with an underscore, are skipped
+// since they cannot be managed, and so are checks and notification endpoints
+// of kinds the provider has no resource for.
+func Generate(ctx context.Context, settings GenerateSettings, w io.Writer) error {
+	host, transport, err := generateTransport(ctx, settings)
+
+	if err != nil {
+		return err
+	}
+
+	client := newInfluxClient(host, settings.Token, transport)
+	defer client.Close()
+
+	name := types.StringNull()
+
+	if settings.Org != "" {
+		name = types.StringValue(settings.Org)
+	}
+
+	organization, err := findOrganization(ctx, client, name)
+
+	if err != nil {
+		return fmt.Errorf("could not find the organization: %w", err)
+	}
+
+	if organization.Id == nil {
+		return fmt.Errorf("organization %s has no id", organization.Name)
+	}
+
+	orgID := *organization.Id
+
+	var out strings.Builder
+	names := map[string]int{}
+
+	fmt.Fprintf(&out, "# Generated from %s, review before applying.\n", settings.Host)
+
+	writeGeneratedResource(&out, "influxdbv2_organization", uniqueResourceName(names, organization.Name), orgID, [][2]string{
+		{"name", hclString(organization.Name)},
+		{"description", optionalHCLString(organization.Description)},
+	})
+
+	if err := generateMembers(ctx, &out, names, organizationMemberships(client), "influxdbv2_organization_member", "org_id", orgID, organization.Name); err != nil {
+		return err
+	}
+
+	buckets, err := influxrest.ListBuckets(ctx, client, domain.GetBucketsParams{OrgID: &orgID})
+
+	if err != nil {
+		return fmt.Errorf("could not list the buckets of organization %s: %w", organization.Name, err)
+	}
+
+	for _, bucket := range buckets {
+		if strings.HasPrefix(bucket.Name, "_") || bucket.Id == nil {
+			continue
+		}
+
+		writeGeneratedResource(&out, "influxdbv2_bucket", uniqueResourceName(names, bucket.Name), *bucket.Id, [][2]string{
+			{"name", hclString(bucket.Name)},
+			{"org_id", hclString(orgID)},
+			{"description", optionalHCLString(bucket.Description)},
+			{"retention_rules", hclRetentionRules(bucket.RetentionRules)},
+			{"schema_type", optionalHCLString((*string)(bucket.SchemaType))},
+		})
+
+		if err := generateMembers(ctx, &out, names, bucketMemberships(client), "influxdbv2_bucket_member", "bucket_id", *bucket.Id, bucket.Name); err != nil {
+			return err
+		}
+	}
+
+	tasks, err := influxrest.ListTasks(ctx, client, domain.GetTasksParams{OrgID: &orgID})
+
+	if err != nil {
+		return fmt.Errorf("could not list the tasks of organization %s: %w", organization.Name, err)
+	}
+
+	for _, task := range tasks {
+		status := ""
+
+		if task.Status != nil {
+			status = hclString(string(*task.Status))
+		}
+
+		writeGeneratedResource(&out, "influxdbv2_task", uniqueResourceName(names, task.Name), task.Id, [][2]string{
+			{"org_id", hclString(orgID)},
+			{"flux", hclString(task.Flux)},
+			{"status", status},
+		})
+	}
+
+	checks, err := influxrest.ListChecks(ctx, client, orgID)
+
+	if err != nil {
+		return fmt.Errorf("could not list the checks of organization %s: %w", organization.Name, err)
+	}
+
+	for _, check := range checks {
+		if check.Type != "custom" {
+			continue
+		}
+
+		writeGeneratedResource(&out, "influxdbv2_check_custom", uniqueResourceName(names, check.Name), check.Id, [][2]string{
+			{"org_id", hclString(orgID)},
+			{"name", hclString(check.Name)},
+			{"query", hclString(check.Query.Text)},
+			{"status", optionalHCLString(&check.Status)},
+		})
+	}
+
+	endpoints, err := influxrest.ListTelegramEndpoints(ctx, client, orgID)
+
+	if err != nil {
+		return fmt.Errorf("could not list the notification endpoints of organization %s: %w", organization.Name, err)
+	}
+
+	for _, endpoint := range endpoints {
+		writeGeneratedResource(&out, "influxdbv2_notification_endpoint_telegram", uniqueResourceName(names, endpoint.Name), endpoint.Id, [][2]string{
+			{"org_id", hclString(orgID)},
+			{"name", hclString(endpoint.Name)},
+			// The server never returns the bot token, so plans fail until it is set.
+			{"token", "null # set the bot token, the server does not return it"},
+			{"channel", hclString(endpoint.Channel)},
+			{"status", optionalHCLString(&endpoint.Status)},
+		})
+	}
+
+	authorizations, err := client.AuthorizationsAPI().FindAuthorizationsByOrgID(ctx, orgID)
+
+	if err != nil {
+		return fmt.Errorf("could not list the authorizations of organization %s: %w", organization.Name, err)
+	}
+
+	for _, authorization := range *authorizations {
+		if authorization.Id == nil {
+			continue
+		}
+
+		name := "authorization"
+
+		if authorization.Description != nil && *authorization.Description != "" {
+			name = *authorization.Description
+		}
+
+		status := ""
+
+		if authorization.Status != nil {
+			status = hclString(string(*authorization.Status))
+		}
+
+		writeGeneratedResource(&out, "influxdbv2_authorization", uniqueResourceName(names, name), *authorization.Id, [][2]string{
+			{"org_id", hclString(orgID)},
+			{"user_id", optionalHCLString(authorization.UserID)},
+			{"description", optionalHCLString(authorization.Description)},
+			{"status", status},
+			{"permissions", hclPermissions(authorization.Permissions)},
+		})
+	}
+
+	_, err = io.WriteString(w, out.String())
+
+	return err
+}
+
+// generateTransport returns the host and transport of the client of
+// Generate, applying the TLS, unix socket and authorization scheme settings
+// the way the provider configuration does.
+func generateTransport(ctx context.Context, settings GenerateSettings) (string, http.RoundTripper, error) {
+	if settings.AuthScheme != "" {
+		if err := validateOneOf("auth_scheme", settings.AuthScheme, authSchemes); err != nil {
+			return "", nil, err
+		}
+	}
+
+	config := InfluxdbV2ProviderModel{
+		TLSMinVersion:   types.StringNull(),
+		TLSCipherSuites: types.ListNull(types.StringType),
+	}
+
+	if settings.TLSMinVersion != "" {
+		config.TLSMinVersion = types.StringValue(settings.TLSMinVersion)
+	}
+
+	var diags diag.Diagnostics
+
+	if len(settings.TLSCipherSuites) > 0 {
+		config.TLSCipherSuites, diags = types.ListValueFrom(ctx, types.StringType, settings.TLSCipherSuites)
+	}
+
+	transport := tlsTransport(ctx, config, &diags)
+
+	if diags.HasError() {
+		return "", nil, diagnosticsError(diags)
+	}
+
+	host := settings.Host
+
+	if socket, ok := unixSocketPath(host); ok {
+		if !config.TLSMinVersion.IsNull() || !config.TLSCipherSuites.IsNull() {
+			return "", nil, errors.New("TLS options do not apply to unix socket hosts")
+		}
+
+		transport = newUnixSocketTransport(socket)
+		host = unixSocketHost
+	}
+
+	// A failed detection keeps the token scheme, the requests then report
+	// the actual error.
+	transport, _ = withAuthScheme(ctx, host, settings.Token, settings.AuthScheme, transport)
+
+	return host, transport, nil
+}
+
+// diagnosticsError converts the first error of diags into an error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	return nil
+}
+
+// generateMembers writes an import block and resource configuration for
+// each member and owner of the organization or bucket resourceID, called
+// resourceName. Owners get the owner role even when also listed as members.
+func generateMembers(ctx context.Context, out *strings.Builder, names map[string]int, m memberships, resourceType string, idAttribute string, resourceID string, resourceName string) error {
+	members, err := m.members(ctx, resourceID)
+
+	if err != nil {
+		return fmt.Errorf("could not list the members of %s %s: %w", m.kind, resourceName, err)
+	}
+
+	owners, err := m.owners(ctx, resourceID)
+
+	if err != nil {
+		return fmt.Errorf("could not list the owners of %s %s: %w", m.kind, resourceName, err)
+	}
+
+	type user struct{ id, name, role string }
+
+	var users []user
+	owned := map[string]bool{}
+
+	if owners != nil {
+		for _, owner := range *owners {
+			if owner.Id != nil {
+				owned[*owner.Id] = true
+				users = append(users, user{*owner.Id, owner.Name, membershipRoleOwner})
+			}
+		}
+	}
+
+	if members != nil {
+		for _, member := range *members {
+			if member.Id != nil && !owned[*member.Id] {
+				users = append(users, user{*member.Id, member.Name, membershipRoleMember})
+			}
+		}
+	}
+
+	for _, u := range users {
+		writeGeneratedResource(out, resourceType, uniqueResourceName(names, resourceName+"_"+u.name), membershipID(resourceID, u.id), [][2]string{
+			{idAttribute, hclString(resourceID)},
+			{"user_id", hclString(u.id)},
+			{"role", hclString(u.role)},
+		})
+	}
+
+	return nil
+}
+
+// writeGeneratedResource writes an import block for id and a resource block
+// with the attributes whose value is not empty.
+func writeGeneratedResource(out *strings.Builder, resourceType string, name string, id string, attributes [][2]string) {
+	fmt.Fprintf(out, "\nimport {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, name, hclString(id))
+	fmt.Fprintf(out, "resource %q %q {\n", resourceType, name)
+
+	width := 0
+
+	for _, attribute := range attributes {
+		if attribute[1] != "" && len(attribute[0]) > width {
+			width = len(attribute[0])
+		}
+	}
+
+	for _, attribute := range attributes {
+		if attribute[1] != "" {
+			fmt.Fprintf(out, "  %-*s = %s\n", width, attribute[0], attribute[1])
+		}
+	}
+
+	out.WriteString("}\n")
+}
+
+var resourceNameInvalid = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// uniqueResourceName turns name into a Terraform identifier that was not
+// returned before.
+func uniqueResourceName(names map[string]int, name string) string {
+	identifier := strings.Trim(resourceNameInvalid.ReplaceAllString(strings.ToLower(name), "_"), "_")
+
+	if identifier == "" || (identifier[0] >= '0' && identifier[0] <= '9') || identifier[0] == '-' {
+		identifier = "r_" + identifier
+	}
+
+	names[identifier]++
+
+	if count := names[identifier]; count > 1 {
+		identifier = fmt.Sprintf("%s_%d", identifier, count)
+	}
+
+	return identifier
+}
+
+// hclString quotes value as an HCL string literal, escaping the template
+// sequences HCL would otherwise interpret.
+func hclString(value string) string {
+	var quoted strings.Builder
+
+	quoted.WriteByte('"')
+
+	for i, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteRune(r)
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\r':
+			quoted.WriteString(`\r`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&quoted, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			// Doubling the sign escapes the ${ and %{ template sequences.
+			quoted.WriteRune(r)
+			quoted.WriteRune(r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+
+	quoted.WriteByte('"')
+
+	return quoted.String()
+}
+
+func optionalHCLString(value *string) string {
+	if value == nil || *value == "" {
+		return ""
+	}
+
+	return hclString(*value)
+}
+
+// hclPermissions renders the permissions of an authorization as the
+// permissions attribute of influxdbv2_authorization.
+func hclPermissions(permissions *[]domain.Permission) string {
+	if permissions == nil || len(*permissions) == 0 {
+		return ""
+	}
+
+	var elements []string
+
+	for _, permission := range *permissions {
+		resource := []string{"type = " + hclString(string(permission.Resource.Type))}
+
+		if permission.Resource.Id != nil {
+			resource = append(resource, "id = "+hclString(*permission.Resource.Id))
+		}
+
+		if permission.Resource.OrgID != nil {
+			resource = append(resource, "org_id = "+hclString(*permission.Resource.OrgID))
+		}
+
+		elements = append(elements, fmt.Sprintf("    { action = %s, resource = { %s } },\n",
+			hclString(string(permission.Action)), strings.Join(resource, ", ")))
+	}
+
+	return "[\n" + strings.Join(elements, "") + "  ]"
+}
+
+func hclRetentionRules(rules []domain.RetentionRule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+
+	var elements []string
+
+	for _, rule := range rules {
+		retentionType := string(domain.RetentionRuleTypeExpire)
+
+		if rule.Type != nil {
+			retentionType = string(*rule.Type)
+		}
+
+		elements = append(elements, fmt.Sprintf("{ every_seconds = %d, retention_type = %s }", rule.EverySeconds, hclString(retentionType)))
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v2/orgs":
+			_, _ = w.Write([]byte(`{"orgs":[{"id":"0000000000000001","name":"Staging Org"}]}`))
+		case "/api/v2/orgs/0000000000000001/members":
+			_, _ = w.Write([]byte(`{"users":[{"id":"00000000000000a1","name":"admin"},{"id":"00000000000000a2","name":"ops"}]}`))
+		case "/api/v2/orgs/0000000000000001/owners":
+			_, _ = w.Write([]byte(`{"users":[{"id":"00000000000000a1","name":"admin"}]}`))
+		case "/api/v2/buckets":
+			_, _ = w.Write([]byte(`{"buckets":[
+				{"id":"0000000000000002","orgID":"0000000000000001","name":"_monitoring"},
+				{"id":"0000000000000003","orgID":"0000000000000001","name":"métricas/prod","description":"cost ${x}",
+				 "retentionRules":[{"type":"expire","everySeconds":7776000}]},
+				{"id":"0000000000000004","orgID":"0000000000000001","name":"1 week"}
+			]}`))
+		case "/api/v2/buckets/0000000000000004/members":
+			_, _ = w.Write([]byte(`{"users":[{"id":"00000000000000a2","name":"ops"}]}`))
+		case "/api/v2/buckets/0000000000000003/members", "/api/v2/buckets/0000000000000003/owners", "/api/v2/buckets/0000000000000004/owners":
+			_, _ = w.Write([]byte(`{"users":[]}`))
+		case "/api/v2/tasks":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"0000000000000005","orgID":"0000000000000001","name":"rollup","status":"inactive",
+				"flux":"option task = {name: \"rollup\", every: 1h}\nfrom(bucket: \"a\")"}]}`))
+		case "/api/v2/checks":
+			_, _ = w.Write([]byte(`{"checks":[
+				{"id":"0000000000000006","orgID":"0000000000000001","name":"cpu","type":"custom","status":"active","query":{"text":"from(bucket: \"a\")"}},
+				{"id":"0000000000000007","orgID":"0000000000000001","name":"threshold","type":"threshold","status":"active"}
+			]}`))
+		case "/api/v2/notificationEndpoints":
+			_, _ = w.Write([]byte(`{"notificationEndpoints":[
+				{"id":"0000000000000008","orgID":"0000000000000001","name":"alerts","type":"telegram","status":"active","channel":"-100"},
+				{"id":"0000000000000009","orgID":"0000000000000001","name":"hook","type":"http","status":"active"}
+			]}`))
+		case "/api/v2/authorizations":
+			_, _ = w.Write([]byte(`{"authorizations":[{"id":"000000000000000a","orgID":"0000000000000001","userID":"00000000000000a2",
+				"description":"ci","status":"active",
+				"permissions":[{"action":"read","resource":{"type":"buckets","id":"0000000000000003","orgID":"0000000000000001"}}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+
+	if err := Generate(context.Background(), GenerateSettings{Host: server.URL, Token: "token"}, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	generated := out.String()
+
+	for _, expected := range []string{
+		"import {\n  to = influxdbv2_organization.staging_org\n  id = \"0000000000000001\"\n}",
+		"resource \"influxdbv2_organization\" \"staging_org\" {\n  name = \"Staging Org\"\n}",
+		"import {\n  to = influxdbv2_bucket.m_tricas_prod\n  id = \"0000000000000003\"\n}",
+		"  name            = \"métricas/prod\"\n",
+		"  description     = \"cost $${x}\"\n",
+		"  retention_rules = [{ every_seconds = 7776000, retention_type = \"expire\" }]\n",
+		"resource \"influxdbv2_bucket\" \"r_1_week\" {",
+		"import {\n  to = influxdbv2_organization_member.staging_org_admin\n  id = \"0000000000000001/00000000000000a1\"\n}",
+		"resource \"influxdbv2_organization_member\" \"staging_org_admin\" {\n  org_id  = \"0000000000000001\"\n  user_id = \"00000000000000a1\"\n  role    = \"owner\"\n}",
+		"resource \"influxdbv2_organization_member\" \"staging_org_ops\" {\n  org_id  = \"0000000000000001\"\n  user_id = \"00000000000000a2\"\n  role    = \"member\"\n}",
+		"resource \"influxdbv2_bucket_member\" \"r_1_week_ops\" {\n  bucket_id = \"0000000000000004\"\n",
+		"resource \"influxdbv2_task\" \"rollup\" {\n  org_id = \"0000000000000001\"\n" +
+			"  flux   = \"option task = {name: \\\"rollup\\\", every: 1h}\\nfrom(bucket: \\\"a\\\")\"\n  status = \"inactive\"\n}",
+		"resource \"influxdbv2_check_custom\" \"cpu\" {",
+		"  token   = null # set the bot token, the server does not return it\n  channel = \"-100\"\n",
+		"import {\n  to = influxdbv2_authorization.ci\n  id = \"000000000000000a\"\n}",
+		"  permissions = [\n    { action = \"read\", resource = { type = \"buckets\", id = \"0000000000000003\", org_id = \"0000000000000001\" } },\n  ]\n",
+	} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("expected the output to contain %q, got:\n%s", expected, generated)
+		}
+	}
+
+	for _, unexpected := range []string{"_monitoring", "threshold", "hook", "influxdbv2_organization_member.staging_org_admin_2"} {
+		if strings.Contains(generated, unexpected) {
+			t.Errorf("expected the output not to contain %q, got:\n%s", unexpected, generated)
+		}
+	}
+}
+
+func TestGenerateAuthScheme(t *testing.T) {
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	settings := GenerateSettings{Host: server.URL, Token: "token", AuthScheme: authSchemeBearer}
+
+	if err := Generate(context.Background(), settings, io.Discard); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if authorization != "Bearer token" {
+		t.Errorf("expected the bearer scheme, got %q", authorization)
+	}
+
+	settings.AuthScheme = "basic"
+
+	if err := Generate(context.Background(), settings, io.Discard); err == nil || !strings.Contains(err.Error(), "auth_scheme") {
+		t.Errorf("expected an invalid scheme error, got %v", err)
+	}
+
+	settings = GenerateSettings{Host: server.URL, Token: "token", TLSMinVersion: "1.0"}
+
+	if err := Generate(context.Background(), settings, io.Discard); err == nil || !strings.Contains(err.Error(), "TLS version") {
+		t.Errorf("expected an invalid TLS version error, got %v", err)
+	}
+}
+
+func TestUniqueResourceName(t *testing.T) {
+	names := map[string]int{}
+
+	for name, expected := range map[string]string{"Team A": "team_a", "team-a": "team-a"} {
+		if got := uniqueResourceName(names, name); got != expected {
+			t.Errorf("%q: expected %s, got %s", name, expected, got)
+		}
+	}
+
+	if got := uniqueResourceName(names, "team a"); got != "team_a_2" {
+		t.Errorf("expected a numbered duplicate, got %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringValueOrNull maps optional API strings to Terraform values, treating
+// the empty string the server returns for unset fields as null.
+func stringValueOrNull(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(*value)
+}
+
+// timeValue maps API timestamps to Terraform values.
+func timeValue(value *time.Time) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value.String())
+}
+
+// selectByName returns the only item called name, or an error naming what was
+// searched and, when the name is ambiguous, the ids of the candidates.
+func selectByName[T any](kind string, orgID string, name string, items []T, nameOf func(T) string, idOf func(T) string) (T, error) {
+	var matches []T
+
+	for _, item := range items {
+		if nameOf(item) == name {
+			matches = append(matches, item)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		var empty T
+
+		return empty, fmt.Errorf("no %s named %q found in organization %s", kind, name, orgID)
+	default:
+		ids := make([]string, 0, len(matches))
+
+		for _, item := range matches {
+			ids = append(ids, idOf(item))
+		}
+
+		var empty T
+
+		return empty, fmt.Errorf("%d %ss named %q found in organization %s, candidates: %s", len(matches), kind, name, orgID, strings.Join(ids, ", "))
+	}
+}
+
+// validateLookup checks that a singular data source is configured with
+// either id or both org_id and name.
+func validateLookup(id types.String, orgID types.String, name types.String, diags *diag.Diagnostics) {
+	if !id.IsNull() {
+		if !name.IsNull() {
+			diags.AddAttributeError(path.Root("name"), "Conflicting lookup arguments", "Set either id or org_id and name, not both.")
+		}
+
+		return
+	}
+
+	if orgID.IsNull() || name.IsNull() {
+		diags.AddError("Missing lookup arguments", "Set either id or both org_id and name.")
+	}
+}
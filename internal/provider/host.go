@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// plainHTTPRisk describes how exposed a token sent over plain HTTP to a host
+// is.
+type plainHTTPRisk int
+
+const (
+	// plainHTTPSafe covers hosts that are not http:// URLs and loopback hosts.
+	plainHTTPSafe plainHTTPRisk = iota
+	// plainHTTPPrivate covers private and link local addresses, which are
+	// warned about.
+	plainHTTPPrivate
+	// plainHTTPPublic covers every other host, which is rejected.
+	plainHTTPPublic
+)
+
+// classifyPlainHTTP returns the risk of sending the token to host.
+func classifyPlainHTTP(host string) plainHTTPRisk {
+	parsed, err := url.Parse(host)
+
+	if err != nil || !strings.EqualFold(parsed.Scheme, "http") {
+		return plainHTTPSafe
+	}
+
+	hostname := strings.ToLower(parsed.Hostname())
+
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return plainHTTPSafe
+	}
+
+	ip := net.ParseIP(hostname)
+
+	switch {
+	case ip == nil:
+		return plainHTTPPublic
+	case ip.IsLoopback():
+		return plainHTTPSafe
+	case ip.IsPrivate() || ip.IsLinkLocalUnicast():
+		return plainHTTPPrivate
+	default:
+		return plainHTTPPublic
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "testing"
+
+func TestClassifyPlainHTTP(t *testing.T) {
+	for host, expected := range map[string]plainHTTPRisk{
+		"https://influx.example.com":     plainHTTPSafe,
+		"http://localhost:8086":          plainHTTPSafe,
+		"http://influx.localhost":        plainHTTPSafe,
+		"http://127.0.0.1:8086":          plainHTTPSafe,
+		"http://[::1]:8086":              plainHTTPSafe,
+		"http://10.0.0.12:8086":          plainHTTPPrivate,
+		"http://192.168.1.20":            plainHTTPPrivate,
+		"http://172.16.4.2:8086":         plainHTTPPrivate,
+		"http://169.254.10.1":            plainHTTPPrivate,
+		"http://influx.example.com":      plainHTTPPublic,
+		"HTTP://influx.example.com:8086": plainHTTPPublic,
+		"http://8.8.8.8:8086":            plainHTTPPublic,
+	} {
+		if got := classifyPlainHTTP(host); got != expected {
+			t.Errorf("%s: expected %d, got %d", host, expected, got)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// effectiveLabelAttrTypes describes the elements of the effective_labels
+// attribute of resources that carry labels.
+var effectiveLabelAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}
+
+type effectiveLabelModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// effectiveLabelsAttribute returns the effective_labels attribute of a
+// resource carrying labels, described by description.
+func effectiveLabelsAttribute(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.UseStateForUnknown(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Label id",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Label name",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// labelMatches reports whether ref, a label name or id from default_labels,
+// designates label.
+func labelMatches(label domain.Label, ref string) bool {
+	return (label.Id != nil && *label.Id == ref) || (label.Name != nil && *label.Name == ref)
+}
+
+// flattenLabels converts the labels attached to a resource into the
+// effective_labels set.
+func flattenLabels(ctx context.Context, labels *domain.Labels) (types.Set, diag.Diagnostics) {
+	elements := []effectiveLabelModel{}
+
+	if labels != nil {
+		for _, label := range *labels {
+			elements = append(elements, effectiveLabelModel{
+				Id:   types.StringPointerValue(label.Id),
+				Name: types.StringPointerValue(label.Name),
+			})
+		}
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: effectiveLabelAttrTypes}, elements)
+}
+
+// defaultLabelsApplied reports whether the effective_labels set of a resource
+// already contains every default label, so the plan can keep it as is.
+func defaultLabelsApplied(ctx context.Context, effective types.Set, defaults []string) (bool, diag.Diagnostics) {
+	if effective.IsNull() || effective.IsUnknown() {
+		return len(defaults) == 0, nil
+	}
+
+	var elements []effectiveLabelModel
+
+	diags := effective.ElementsAs(ctx, &elements, false)
+
+	for _, ref := range defaults {
+		found := false
+
+		for _, element := range elements {
+			if element.Id.ValueString() == ref || element.Name.ValueString() == ref {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false, diags
+		}
+	}
+
+	return true, diags
+}
+
+// planEffectiveLabels plans effective_labels as unknown when a provider
+// default_labels entry is missing from the state of an existing resource, so
+// the label is attached again.
+func planEffectiveLabels(ctx context.Context, data *providerData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if data == nil || len(data.defaultLabels) == 0 || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var effective types.Set
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("effective_labels"), &effective)...)
+
+	applied, diags := defaultLabelsApplied(ctx, effective, data.defaultLabels)
+	resp.Diagnostics.Append(diags...)
+
+	if !applied {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), types.SetUnknown(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}))...)
+	}
+}
+
+// attachDefaultLabels attaches the default labels missing from attached using
+// attach. Labels are looked up by name or id in the organization orgID and
+// must already exist.
+func attachDefaultLabels(ctx context.Context, client influxdb2.Client, orgID string, defaults []string, attached *domain.Labels, attach func(labelID string) error) error {
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	available, err := client.LabelsAPI().FindLabelsByOrgID(ctx, orgID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range defaults {
+		if attached != nil && containsLabel(*attached, ref) {
+			continue
+		}
+
+		var label *domain.Label
+
+		for i := range *available {
+			if labelMatches((*available)[i], ref) {
+				label = &(*available)[i]
+
+				break
+			}
+		}
+
+		if label == nil || label.Id == nil {
+			return fmt.Errorf("default label %q not found in organization %s", ref, orgID)
+		}
+
+		if err := attach(*label.Id); err != nil {
+			return fmt.Errorf("could not attach default label %q: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+func containsLabel(labels domain.Labels, ref string) bool {
+	for _, label := range labels {
+		if labelMatches(label, ref) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addBucketLabel attaches the label labelID to the bucket bucketID.
+func addBucketLabel(ctx context.Context, client influxdb2.Client, bucketID string, labelID string) error {
+	_, err := client.APIClient().PostBucketsIDLabels(ctx, &domain.PostBucketsIDLabelsAllParams{
+		BucketID: bucketID,
+		Body:     domain.PostBucketsIDLabelsJSONRequestBody{LabelID: &labelID},
+	})
+
+	return err
+}
+
+// applyTaskDefaultLabels attaches the provider default_labels missing from
+// task and returns the task as stored afterwards.
+func applyTaskDefaultLabels(ctx context.Context, data *providerData, task *domain.Task) (*domain.Task, error) {
+	if len(data.defaultLabels) == 0 {
+		return task, nil
+	}
+
+	err := attachDefaultLabels(ctx, data.client, task.OrgID, data.defaultLabels, task.Labels, func(labelID string) error {
+		_, err := data.client.TasksAPI().AddLabelWithID(ctx, task.Id, labelID)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return findAfterCreate(ctx, data, task.Id, func(ctx context.Context) (*domain.Task, error) {
+		return data.client.TasksAPI().GetTaskByID(ctx, task.Id)
+	})
+}
+
+// addResourceLabel attaches the label labelID to the resource at
+// resourcePath, for example "checks/{id}", through its labels endpoint.
+func addResourceLabel(ctx context.Context, client influxdb2.Client, resourcePath string, labelID string) error {
+	_, err := influxrest.Do(ctx, client, influxrest.Request{
+		Method: http.MethodPost,
+		Path:   resourcePath + "/labels",
+		Body:   map[string]string{"labelID": labelID},
+	})
+
+	return err
+}
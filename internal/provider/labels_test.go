@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestDefaultLabelsApplied(t *testing.T) {
+	ctx := context.Background()
+	id, name := "0000000000000040", "managed-by:terraform"
+
+	effective, diags := flattenLabels(ctx, &domain.Labels{{Id: &id, Name: &name}})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for _, test := range []struct {
+		defaults []string
+		applied  bool
+	}{
+		{defaults: []string{name}, applied: true},
+		{defaults: []string{id}, applied: true},
+		{defaults: []string{name, "team:platform"}, applied: false},
+	} {
+		applied, _ := defaultLabelsApplied(ctx, effective, test.defaults)
+
+		if applied != test.applied {
+			t.Errorf("%v: expected %t, got %t", test.defaults, test.applied, applied)
+		}
+	}
+}
+
+func TestAttachDefaultLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"labels":[
+			{"id":"0000000000000040","name":"managed-by:terraform","orgID":"0000000000000001"},
+			{"id":"0000000000000041","name":"team:platform","orgID":"0000000000000001"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+	attachedID, attachedName := "0000000000000040", "managed-by:terraform"
+
+	var added []string
+
+	err := attachDefaultLabels(ctx, client, "0000000000000001", []string{"managed-by:terraform", "0000000000000041"}, &domain.Labels{{Id: &attachedID, Name: &attachedName}}, func(labelID string) error {
+		added = append(added, labelID)
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(added) != 1 || added[0] != "0000000000000041" {
+		t.Errorf("expected only the missing label to be attached, got %v", added)
+	}
+
+	err = attachDefaultLabels(ctx, client, "0000000000000001", []string{"missing"}, nil, func(string) error { return nil })
+
+	if err == nil || !strings.Contains(err.Error(), `default label "missing" not found`) {
+		t.Errorf("expected a missing label error, got %v", err)
+	}
+}
+
+func TestApplyTaskDefaultLabels(t *testing.T) {
+	labels := `[]`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/labels":
+			_, _ = w.Write([]byte(`{"labels": [{"id": "0000000000000040", "name": "managed-by:terraform", "orgID": "0000000000000001"}]}`))
+		case "POST /api/v2/tasks/0000000000000002/labels":
+			labels = `[{"id": "0000000000000040", "name": "managed-by:terraform"}]`
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"label": {"id": "0000000000000040", "name": "managed-by:terraform"}}`))
+		case "GET /api/v2/tasks/0000000000000002":
+			_, _ = w.Write([]byte(`{"id": "0000000000000002", "orgID": "0000000000000001", "name": "cpu", "flux": "", "labels": ` + labels + `}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	data := newProviderData(server.URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	data.defaultLabels = []string{"managed-by:terraform"}
+
+	task, err := applyTaskDefaultLabels(context.Background(), data, &domain.Task{Id: "0000000000000002", OrgID: "0000000000000001"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if task.Labels == nil || !containsLabel(*task.Labels, "managed-by:terraform") {
+		t.Errorf("expected the default label to be attached, got %v", task.Labels)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &limitsDataSource{}
+	_ datasource.DataSourceWithConfigure = &limitsDataSource{}
+)
+
+func LimitsDataSource() datasource.DataSource {
+	return &limitsDataSource{}
+}
+
+type limitsDataSource struct {
+	client influxdb2.Client
+}
+
+// limitsDataSourceModel describes the data source data model.
+type limitsDataSourceModel struct {
+	Id                   types.String                    `tfsdk:"id"`
+	OrgID                types.String                    `tfsdk:"org_id"`
+	Rate                 limitsRateModel                 `tfsdk:"rate"`
+	Bucket               limitsBucketModel               `tfsdk:"bucket"`
+	Dashboard            limitsDashboardModel            `tfsdk:"dashboard"`
+	Task                 limitsTaskModel                 `tfsdk:"task"`
+	Check                limitsCheckModel                `tfsdk:"check"`
+	NotificationRule     limitsNotificationRuleModel     `tfsdk:"notification_rule"`
+	NotificationEndpoint limitsNotificationEndpointModel `tfsdk:"notification_endpoint"`
+}
+
+type limitsRateModel struct {
+	ReadKBs                 types.Int64 `tfsdk:"read_kbs"`
+	WriteKBs                types.Int64 `tfsdk:"write_kbs"`
+	ConcurrentReadRequests  types.Int64 `tfsdk:"concurrent_read_requests"`
+	ConcurrentWriteRequests types.Int64 `tfsdk:"concurrent_write_requests"`
+	Cardinality             types.Int64 `tfsdk:"cardinality"`
+}
+
+type limitsBucketModel struct {
+	MaxBuckets          types.Int64 `tfsdk:"max_buckets"`
+	MaxRetentionSeconds types.Int64 `tfsdk:"max_retention_seconds"`
+}
+
+type limitsDashboardModel struct {
+	MaxDashboards types.Int64 `tfsdk:"max_dashboards"`
+}
+
+type limitsTaskModel struct {
+	MaxTasks types.Int64 `tfsdk:"max_tasks"`
+}
+
+type limitsCheckModel struct {
+	MaxChecks types.Int64 `tfsdk:"max_checks"`
+}
+
+type limitsNotificationRuleModel struct {
+	MaxNotifications types.Int64    `tfsdk:"max_notifications"`
+	BlockedTypes     []types.String `tfsdk:"blocked_types"`
+}
+
+type limitsNotificationEndpointModel struct {
+	BlockedTypes []types.String `tfsdk:"blocked_types"`
+}
+
+// orgLimits is the limits document returned by InfluxDB Cloud.
+type orgLimits struct {
+	Limits struct {
+		Rate struct {
+			ReadKBs                 int64 `json:"readKBs"`
+			WriteKBs                int64 `json:"writeKBs"`
+			ConcurrentReadRequests  int64 `json:"concurrentReadRequests"`
+			ConcurrentWriteRequests int64 `json:"concurrentWriteRequests"`
+			Cardinality             int64 `json:"cardinality"`
+		} `json:"rate"`
+		Bucket struct {
+			MaxBuckets int64 `json:"maxBuckets"`
+			// MaxRetentionDuration is expressed in nanoseconds.
+			MaxRetentionDuration int64 `json:"maxRetentionDuration"`
+		} `json:"bucket"`
+		Dashboard struct {
+			MaxDashboards int64 `json:"maxDashboards"`
+		} `json:"dashboard"`
+		Task struct {
+			MaxTasks int64 `json:"maxTasks"`
+		} `json:"task"`
+		Check struct {
+			MaxChecks int64 `json:"maxChecks"`
+		} `json:"check"`
+		NotificationRule struct {
+			MaxNotifications         int64  `json:"maxNotifications"`
+			BlockedNotificationRules string `json:"blockedNotificationRules"`
+		} `json:"notificationRule"`
+		NotificationEndpoint struct {
+			BlockedNotificationEndpoints string `json:"blockedNotificationEndpoints"`
+		} `json:"notificationEndpoint"`
+	} `json:"limits"`
+}
+
+// blockedTypes splits the comma separated lists of blocked notification types.
+func blockedTypes(value string) []types.String {
+	blocked := []types.String{}
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			blocked = append(blocked, types.StringValue(name))
+		}
+	}
+
+	return blocked
+}
+
+func int64Attributes(names ...string) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{}
+
+	for _, name := range names {
+		attributes[name] = schema.Int64Attribute{Computed: true}
+	}
+
+	return attributes
+}
+
+func (d *limitsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_limits"
+}
+
+func (d *limitsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	notificationRule := int64Attributes("max_notifications")
+	notificationRule["blocked_types"] = schema.ListAttribute{ElementType: types.StringType, Computed: true}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Limits configured for an InfluxDB Cloud organization. Requires InfluxDB Cloud.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization id the limits were read for",
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+			},
+			"rate": schema.SingleNestedAttribute{
+				MarkdownDescription: "Read and write rate limits and the series cardinality limit",
+				Computed:            true,
+				Attributes:          int64Attributes("read_kbs", "write_kbs", "concurrent_read_requests", "concurrent_write_requests", "cardinality"),
+			},
+			"bucket": schema.SingleNestedAttribute{
+				MarkdownDescription: "Bucket count and maximum retention, in seconds",
+				Computed:            true,
+				Attributes:          int64Attributes("max_buckets", "max_retention_seconds"),
+			},
+			"dashboard": schema.SingleNestedAttribute{
+				MarkdownDescription: "Dashboard count limit",
+				Computed:            true,
+				Attributes:          int64Attributes("max_dashboards"),
+			},
+			"task": schema.SingleNestedAttribute{
+				MarkdownDescription: "Task count limit",
+				Computed:            true,
+				Attributes:          int64Attributes("max_tasks"),
+			},
+			"check": schema.SingleNestedAttribute{
+				MarkdownDescription: "Check count limit",
+				Computed:            true,
+				Attributes:          int64Attributes("max_checks"),
+			},
+			"notification_rule": schema.SingleNestedAttribute{
+				MarkdownDescription: "Notification rule count limit and blocked rule types",
+				Computed:            true,
+				Attributes:          notificationRule,
+			},
+			"notification_endpoint": schema.SingleNestedAttribute{
+				MarkdownDescription: "Blocked notification endpoint types",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"blocked_types": schema.ListAttribute{ElementType: types.StringType, Computed: true},
+				},
+			},
+		},
+	}
+}
+
+func (d *limitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// limitsToModel maps the limits document into the data source model.
+func limitsToModel(document orgLimits, state *limitsDataSourceModel) {
+	limits := document.Limits
+
+	state.Rate = limitsRateModel{
+		ReadKBs:                 types.Int64Value(limits.Rate.ReadKBs),
+		WriteKBs:                types.Int64Value(limits.Rate.WriteKBs),
+		ConcurrentReadRequests:  types.Int64Value(limits.Rate.ConcurrentReadRequests),
+		ConcurrentWriteRequests: types.Int64Value(limits.Rate.ConcurrentWriteRequests),
+		Cardinality:             types.Int64Value(limits.Rate.Cardinality),
+	}
+	state.Bucket = limitsBucketModel{
+		MaxBuckets:          types.Int64Value(limits.Bucket.MaxBuckets),
+		MaxRetentionSeconds: types.Int64Value(limits.Bucket.MaxRetentionDuration / 1e9),
+	}
+	state.Dashboard = limitsDashboardModel{MaxDashboards: types.Int64Value(limits.Dashboard.MaxDashboards)}
+	state.Task = limitsTaskModel{MaxTasks: types.Int64Value(limits.Task.MaxTasks)}
+	state.Check = limitsCheckModel{MaxChecks: types.Int64Value(limits.Check.MaxChecks)}
+	state.NotificationRule = limitsNotificationRuleModel{
+		MaxNotifications: types.Int64Value(limits.NotificationRule.MaxNotifications),
+		BlockedTypes:     blockedTypes(limits.NotificationRule.BlockedNotificationRules),
+	}
+	state.NotificationEndpoint = limitsNotificationEndpointModel{
+		BlockedTypes: blockedTypes(limits.NotificationEndpoint.BlockedNotificationEndpoints),
+	}
+}
+
+func (d *limitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state limitsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := influxrest.Do(ctx, d.client, influxrest.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("orgs/%s/limits", url.PathEscape(state.OrgID.ValueString())),
+	})
+
+	if err != nil {
+		addCloudAPIError(ctx, &resp.Diagnostics, err,
+			"The influxdbv2_limits data source",
+			"Error reading limits",
+			fmt.Sprintf("Could not read limits for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	var document orgLimits
+
+	if err := json.Unmarshal(payload, &document); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading limits",
+			fmt.Sprintf("Could not decode limits for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	state.Id = state.OrgID
+	limitsToModel(document, &state)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
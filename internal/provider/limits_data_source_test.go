@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLimitsToModel(t *testing.T) {
+	var document orgLimits
+
+	err := json.Unmarshal([]byte(`{"limits":{
+		"orgID":"0000000000000001",
+		"rate":{"readKBs":1000,"concurrentReadRequests":10,"writeKBs":17,"concurrentWriteRequests":5,"cardinality":10000},
+		"bucket":{"maxBuckets":2,"maxRetentionDuration":2592000000000000},
+		"dashboard":{"maxDashboards":5},
+		"task":{"maxTasks":5},
+		"notificationRule":{"maxNotifications":2,"blockedNotificationRules":"comma, http"},
+		"notificationEndpoint":{"blockedNotificationEndpoints":""},
+		"check":{"maxChecks":2}
+	}}`), &document)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state limitsDataSourceModel
+	limitsToModel(document, &state)
+
+	if state.Bucket.MaxRetentionSeconds.ValueInt64() != 2592000 {
+		t.Errorf("expected 30 days of retention in seconds, got %s", state.Bucket.MaxRetentionSeconds)
+	}
+
+	if state.Rate.Cardinality.ValueInt64() != 10000 || state.Task.MaxTasks.ValueInt64() != 5 {
+		t.Errorf("unexpected limits: %+v", state)
+	}
+
+	blocked := state.NotificationRule.BlockedTypes
+
+	if len(blocked) != 2 || blocked[1].ValueString() != "http" {
+		t.Errorf("unexpected blocked notification rules: %v", blocked)
+	}
+
+	if state.NotificationEndpoint.BlockedTypes == nil || len(state.NotificationEndpoint.BlockedTypes) != 0 {
+		t.Errorf("expected no blocked endpoints, got %v", state.NotificationEndpoint.BlockedTypes)
+	}
+}
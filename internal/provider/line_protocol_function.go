@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &lineProtocolFunction{}
+
+func LineProtocolFunction() function.Function {
+	return &lineProtocolFunction{}
+}
+
+type lineProtocolFunction struct{}
+
+var (
+	measurementEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`, " ", `\ `)
+	stringFieldEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+func (f *lineProtocolFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "line_protocol"
+}
+
+func (f *lineProtocolFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an escaped line protocol point",
+		MarkdownDescription: "Returns a single line protocol point with the measurement, tag keys and values and field keys escaped. " +
+			"Field values are typed from their text: `true` and `false` are booleans, integers are written with the `i` suffix, " +
+			"other numbers are floats and everything else is a quoted string. The timestamp is either RFC3339 or an integer " +
+			"epoch in nanoseconds; null omits it.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "measurement",
+				MarkdownDescription: "Measurement name",
+			},
+			function.MapParameter{
+				Name:                "tags",
+				MarkdownDescription: "Tag set of the point, may be null",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+			},
+			function.MapParameter{
+				Name:                "fields",
+				MarkdownDescription: "Field set of the point, at least one field is required",
+				ElementType:         types.StringType,
+			},
+			function.StringParameter{
+				Name:                "timestamp",
+				MarkdownDescription: "RFC3339 timestamp or epoch in nanoseconds, may be null",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *lineProtocolFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var measurement string
+	var tags map[string]string
+	var fields map[string]*string
+	var timestamp types.String
+
+	resp.Error = req.Arguments.Get(ctx, &measurement, &tags, &fields, &timestamp)
+
+	if resp.Error != nil {
+		return
+	}
+
+	line, err := formatLineProtocol(measurement, tags, fields, timestamp.ValueString())
+
+	if err != nil {
+		var argErr *lineProtocolArgumentError
+
+		if errors.As(err, &argErr) {
+			resp.Error = function.NewArgumentFuncError(argErr.position, err.Error())
+		} else {
+			resp.Error = function.NewFuncError(err.Error())
+		}
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, line)
+}
+
+// lineProtocolArgumentError attributes a formatting error to one of the
+// function arguments.
+type lineProtocolArgumentError struct {
+	position int64
+	message  string
+}
+
+func (e *lineProtocolArgumentError) Error() string {
+	return e.message
+}
+
+func lineProtocolArgumentErrorf(position int64, format string, args ...any) error {
+	return &lineProtocolArgumentError{position: position, message: fmt.Sprintf(format, args...)}
+}
+
+// formatLineProtocol renders a single point. Tags and fields are written in
+// key order so the result is stable across runs, and an empty timestamp
+// leaves the point time to the server.
+func formatLineProtocol(measurement string, tags map[string]string, fields map[string]*string, timestamp string) (string, error) {
+	if measurement == "" {
+		return "", lineProtocolArgumentErrorf(0, "measurement must not be empty")
+	}
+
+	if strings.ContainsAny(measurement, "\n\r") {
+		return "", lineProtocolArgumentErrorf(0, "measurement must not contain line breaks")
+	}
+
+	var line strings.Builder
+
+	line.WriteString(measurementEscaper.Replace(measurement))
+
+	for _, key := range sortedKeys(tags) {
+		value := tags[key]
+
+		if key == "" || value == "" {
+			return "", lineProtocolArgumentErrorf(1, "tag %q must have a non-empty key and value", key)
+		}
+
+		if strings.ContainsAny(key+value, "\n\r") {
+			return "", lineProtocolArgumentErrorf(1, "tag %q must not contain line breaks", key)
+		}
+
+		line.WriteString("," + tagEscaper.Replace(key) + "=" + tagEscaper.Replace(value))
+	}
+
+	if len(fields) == 0 {
+		return "", lineProtocolArgumentErrorf(2, "at least one field is required")
+	}
+
+	for i, key := range sortedKeys(fields) {
+		if key == "" || strings.ContainsAny(key, "\n\r") {
+			return "", lineProtocolArgumentErrorf(2, "field key %q is invalid", key)
+		}
+
+		value, err := lineProtocolFieldValue(fields[key])
+
+		if err != nil {
+			return "", lineProtocolArgumentErrorf(2, "field %q: %s", key, err)
+		}
+
+		if i == 0 {
+			line.WriteString(" ")
+		} else {
+			line.WriteString(",")
+		}
+
+		line.WriteString(tagEscaper.Replace(key) + "=" + value)
+	}
+
+	if timestamp != "" {
+		nanos, err := lineProtocolTimestamp(timestamp)
+
+		if err != nil {
+			return "", lineProtocolArgumentErrorf(3, "%s", err)
+		}
+
+		line.WriteString(" " + strconv.FormatInt(nanos, 10))
+	}
+
+	return line.String(), nil
+}
+
+// lineProtocolFieldValue types a field value from its text, since Terraform
+// converts a map with mixed element types to a map of strings.
+func lineProtocolFieldValue(value *string) (string, error) {
+	if value == nil {
+		return "", fmt.Errorf("value must not be null")
+	}
+
+	switch *value {
+	case "true", "false":
+		return *value, nil
+	}
+
+	if _, err := strconv.ParseInt(*value, 10, 64); err == nil {
+		return *value + "i", nil
+	}
+
+	if number, err := strconv.ParseFloat(*value, 64); err == nil {
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return "", fmt.Errorf("%s is not a valid field value", *value)
+		}
+
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+
+	return `"` + stringFieldEscaper.Replace(*value) + `"`, nil
+}
+
+func lineProtocolTimestamp(timestamp string) (int64, error) {
+	if nanos, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		return nanos, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+
+	if err != nil {
+		return 0, fmt.Errorf("timestamp %q is neither RFC3339 nor an epoch in nanoseconds", timestamp)
+	}
+
+	return parsed.UnixNano(), nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
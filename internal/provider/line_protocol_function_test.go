@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestFormatLineProtocol(t *testing.T) {
+	tests := []struct {
+		name        string
+		measurement string
+		tags        map[string]string
+		fields      map[string]*string
+		timestamp   string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:        "plain point",
+			measurement: "cpu",
+			tags:        map[string]string{"host": "a"},
+			fields:      map[string]*string{"usage": ptr("0.5")},
+			expected:    "cpu,host=a usage=0.5",
+		},
+		{
+			name:        "measurement escaping",
+			measurement: "cpu load,total",
+			fields:      map[string]*string{"value": ptr("1.5")},
+			expected:    `cpu\ load\,total value=1.5`,
+		},
+		{
+			name:        "equals sign is not escaped in measurement",
+			measurement: "a=b",
+			fields:      map[string]*string{"value": ptr("1.5")},
+			expected:    `a=b value=1.5`,
+		},
+		{
+			name:        "tag escaping",
+			measurement: "cpu",
+			tags:        map[string]string{"data center": "eu,west=1"},
+			fields:      map[string]*string{"value": ptr("1.5")},
+			expected:    `cpu,data\ center=eu\,west\=1 value=1.5`,
+		},
+		{
+			name:        "backslash escaping",
+			measurement: `c:\cpu`,
+			tags:        map[string]string{"path": `C:\temp`},
+			fields:      map[string]*string{"value": ptr(`C:\temp`)},
+			expected:    `c:\\cpu,path=C:\\temp value="C:\\temp"`,
+		},
+		{
+			name:        "field key escaping",
+			measurement: "cpu",
+			fields:      map[string]*string{"usage idle,pct=x": ptr("1.5")},
+			expected:    `cpu usage\ idle\,pct\=x=1.5`,
+		},
+		{
+			name:        "string field escaping",
+			measurement: "log",
+			fields:      map[string]*string{"message": ptr(`said "hi", a=b`)},
+			expected:    `log message="said \"hi\", a=b"`,
+		},
+		{
+			name:        "field types",
+			measurement: "m",
+			fields: map[string]*string{
+				"b": ptr("true"),
+				"f": ptr("-2.25"),
+				"i": ptr("-42"),
+				"s": ptr("True"),
+				"e": ptr("1e3"),
+			},
+			expected: `m b=true,e=1000,f=-2.25,i=-42i,s="True"`,
+		},
+		{
+			name:        "tags and fields sorted",
+			measurement: "m",
+			tags:        map[string]string{"z": "1", "a": "2"},
+			fields:      map[string]*string{"y": ptr("1"), "b": ptr("2")},
+			expected:    "m,a=2,z=1 b=2i,y=1i",
+		},
+		{
+			name:        "epoch timestamp",
+			measurement: "m",
+			fields:      map[string]*string{"v": ptr("1")},
+			timestamp:   "1700000000000000000",
+			expected:    "m v=1i 1700000000000000000",
+		},
+		{
+			name:        "RFC3339 timestamp",
+			measurement: "m",
+			fields:      map[string]*string{"v": ptr("1")},
+			timestamp:   "2023-11-14T22:13:20Z",
+			expected:    "m v=1i 1700000000000000000",
+		},
+		{
+			name:        "RFC3339 timestamp with offset and fraction",
+			measurement: "m",
+			fields:      map[string]*string{"v": ptr("1")},
+			timestamp:   "2023-11-15T00:13:20.5+02:00",
+			expected:    "m v=1i 1700000000500000000",
+		},
+		{
+			name:        "empty field set",
+			measurement: "m",
+			fields:      map[string]*string{},
+			expectedErr: "at least one field is required",
+		},
+		{
+			name:        "null field value",
+			measurement: "m",
+			fields:      map[string]*string{"v": nil},
+			expectedErr: `field "v": value must not be null`,
+		},
+		{
+			name:        "non-finite field value",
+			measurement: "m",
+			fields:      map[string]*string{"v": ptr("NaN")},
+			expectedErr: `field "v": NaN is not a valid field value`,
+		},
+		{
+			name:        "empty tag value",
+			measurement: "m",
+			tags:        map[string]string{"host": ""},
+			fields:      map[string]*string{"v": ptr("1")},
+			expectedErr: `tag "host" must have a non-empty key and value`,
+		},
+		{
+			name:        "empty measurement",
+			fields:      map[string]*string{"v": ptr("1")},
+			expectedErr: "measurement must not be empty",
+		},
+		{
+			name:        "line break",
+			measurement: "m\nx",
+			fields:      map[string]*string{"v": ptr("1")},
+			expectedErr: "measurement must not contain line breaks",
+		},
+		{
+			name:        "invalid timestamp",
+			measurement: "m",
+			fields:      map[string]*string{"v": ptr("1")},
+			timestamp:   "yesterday",
+			expectedErr: `timestamp "yesterday" is neither RFC3339 nor an epoch in nanoseconds`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			line, err := formatLineProtocol(test.measurement, test.tags, test.fields, test.timestamp)
+
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Fatalf("expected error %q, got %v", test.expectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if line != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, line)
+			}
+		})
+	}
+}
+
+func TestLineProtocolFunctionRun(t *testing.T) {
+	fields := types.MapValueMust(types.StringType, map[string]attr.Value{"v": types.StringValue("1")})
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("m"),
+			types.MapNull(types.StringType),
+			fields,
+			types.StringNull(),
+		}),
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	LineProtocolFunction().Run(context.Background(), req, resp)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	if !resp.Result.Value().Equal(types.StringValue("m v=1i")) {
+		t.Errorf("unexpected result %s", resp.Result.Value())
+	}
+
+	req.Arguments = function.NewArgumentsData([]attr.Value{
+		types.StringValue("m"),
+		types.MapNull(types.StringType),
+		types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		types.StringNull(),
+	})
+	resp = &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	LineProtocolFunction().Run(context.Background(), req, resp)
+
+	if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 2 {
+		t.Errorf("expected an error on the fields argument, got %v", resp.Error)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Structured log fields identifying the object a log line is about.
+const (
+	logFieldResourceType = "resource_type"
+	logFieldResourceName = "resource_name"
+	logFieldResourceID   = "resource_id"
+)
+
+// withObjectFields adds the type, and the name and id once known, of the
+// object a CRUD method works on to every log line written with ctx, including
+// the API call logs.
+func withObjectFields(ctx context.Context, resourceType string, name types.String, id types.String) context.Context {
+	ctx = tflog.SetField(ctx, logFieldResourceType, resourceType)
+
+	if !name.IsNull() && !name.IsUnknown() {
+		ctx = tflog.SetField(ctx, logFieldResourceName, name.ValueString())
+	}
+
+	if !id.IsNull() && !id.IsUnknown() {
+		ctx = tflog.SetField(ctx, logFieldResourceID, id.ValueString())
+	}
+
+	return ctx
+}
+
+// apiLogTransport logs the start and end of every InfluxDB API call at debug
+// level, with the fields of the request context.
+type apiLogTransport struct {
+	next http.RoundTripper
+}
+
+func (t *apiLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.SetField(req.Context(), "operation", req.Method+" "+req.URL.Path)
+
+	tflog.Debug(ctx, "InfluxDB API call started")
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	fields := map[string]interface{}{
+		"elapsed_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status_code"] = resp.StatusCode
+	}
+
+	tflog.Debug(ctx, "InfluxDB API call finished", fields)
+
+	return resp, err
+}
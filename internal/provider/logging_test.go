@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+func TestAPICallLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = withObjectFields(ctx, "influxdbv2_bucket", types.StringValue("metrics"), types.StringUnknown())
+
+	if _, err := influxrest.Do(ctx, client, influxrest.Request{Method: http.MethodGet, Path: "buckets/0000000000000001"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected a start and an end log line, got %v", entries)
+	}
+
+	for _, entry := range entries {
+		if entry["operation"] != "GET /api/v2/buckets/0000000000000001" || entry[logFieldResourceType] != "influxdbv2_bucket" || entry[logFieldResourceName] != "metrics" {
+			t.Errorf("expected the operation and object fields, got %v", entry)
+		}
+
+		if _, ok := entry[logFieldResourceID]; ok {
+			t.Errorf("expected no id field before the id is known, got %v", entry)
+		}
+	}
+
+	if entries[0]["@message"] != "InfluxDB API call started" || entries[1]["@message"] != "InfluxDB API call finished" {
+		t.Errorf("unexpected log messages %v", entries)
+	}
+
+	if _, ok := entries[1]["elapsed_ms"]; !ok || entries[1]["status_code"] != float64(http.StatusOK) {
+		t.Errorf("expected the elapsed time and status code, got %v", entries[1])
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &measurementsDataSource{}
+	_ datasource.DataSourceWithConfigure = &measurementsDataSource{}
+)
+
+func MeasurementsDataSource() datasource.DataSource {
+	return &measurementsDataSource{}
+}
+
+type measurementsDataSource struct {
+	client influxdb2.Client
+}
+
+// measurementsDataSourceModel describes the data source data model.
+type measurementsDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Org          types.String `tfsdk:"org"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Start        types.String `tfsdk:"start"`
+	Measurements types.Set    `tfsdk:"measurements"`
+}
+
+func (d *measurementsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_measurements"
+}
+
+func (d *measurementsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Measurements written to a bucket, discovered with the Flux `schema.measurements` function.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Bucket the measurements were read from",
+				Computed:            true,
+			},
+			"org": schema.StringAttribute{
+				MarkdownDescription: "Organization name or id",
+				Required:            true,
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Bucket name",
+				Required:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Earliest time scanned, a duration such as `-90d` or an RFC3339 timestamp. Defaults to the `schema.measurements` default of `-30d`.",
+				Optional:            true,
+			},
+			"measurements": schema.SetAttribute{
+				MarkdownDescription: "Measurement names",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *measurementsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// measurementsQuery returns the Flux query listing the measurements of
+// bucket, scanning from start when it is not empty.
+func measurementsQuery(bucket string, start string) (string, error) {
+	arguments := []string{"bucket: " + fluxString(bucket)}
+
+	if start != "" {
+		startLiteral, err := fluxTime(start)
+
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, "start: "+startLiteral)
+	}
+
+	return schemaQuery("measurements", arguments...), nil
+}
+
+func (d *measurementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state measurementsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := measurementsQuery(state.Bucket.ValueString(), state.Start.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("start"), "Invalid start", err.Error())
+
+		return
+	}
+
+	measurements, err := queryStrings(ctx, d.client, state.Org.ValueString(), query)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading measurements",
+			fmt.Sprintf("Could not list the measurements of bucket %s : %s", state.Bucket, err),
+		)
+
+		return
+	}
+
+	state.Id = state.Bucket
+
+	var diags diag.Diagnostics
+
+	state.Measurements, diags = types.SetValueFrom(ctx, types.StringType, measurements)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
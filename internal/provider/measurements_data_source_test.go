@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestMeasurementsQuery(t *testing.T) {
+	query, err := measurementsQuery(`team "a"`, "-90d")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "import \"influxdata/influxdb/schema\"\n\nschema.measurements(bucket: \"team \\\"a\\\"\", start: -90d)"
+
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query, err = measurementsQuery("metrics", "")
+
+	if err != nil || query != "import \"influxdata/influxdb/schema\"\n\nschema.measurements(bucket: \"metrics\")" {
+		t.Errorf("expected the default start to be left to schema.measurements, got %s %v", query, err)
+	}
+
+	if _, err := measurementsQuery("metrics", "last month"); err == nil {
+		t.Error("expected an invalid start to be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+const (
+	membershipRoleMember = "member"
+	membershipRoleOwner  = "owner"
+)
+
+// membershipRoles lists the roles of a user in an organization or bucket.
+var membershipRoles = []string{membershipRoleMember, membershipRoleOwner}
+
+// errOwnerDowngrade is returned when adopting a membership would demote an
+// existing owner to member.
+var errOwnerDowngrade = errors.New("the user is an owner, adopting it as a member would revoke its ownership")
+
+// memberships lists, adds and removes the members and owners of the
+// resources of one kind, organizations or buckets.
+type memberships struct {
+	kind    string
+	members func(ctx context.Context, resourceID string) (*[]domain.ResourceMember, error)
+	owners  func(ctx context.Context, resourceID string) (*[]domain.ResourceOwner, error)
+	add     map[string]func(ctx context.Context, resourceID string, userID string) error
+	remove  map[string]func(ctx context.Context, resourceID string, userID string) error
+}
+
+func organizationMemberships(client influxdb2.Client) memberships {
+	api := client.OrganizationsAPI()
+
+	return memberships{
+		kind:    "organization",
+		members: api.GetMembersWithID,
+		owners:  api.GetOwnersWithID,
+		add: map[string]func(ctx context.Context, resourceID string, userID string) error{
+			membershipRoleMember: func(ctx context.Context, orgID string, userID string) error {
+				_, err := api.AddMemberWithID(ctx, orgID, userID)
+				return err
+			},
+			membershipRoleOwner: func(ctx context.Context, orgID string, userID string) error {
+				_, err := api.AddOwnerWithID(ctx, orgID, userID)
+				return err
+			},
+		},
+		remove: map[string]func(ctx context.Context, resourceID string, userID string) error{
+			membershipRoleMember: api.RemoveMemberWithID,
+			membershipRoleOwner:  api.RemoveOwnerWithID,
+		},
+	}
+}
+
+func bucketMemberships(client influxdb2.Client) memberships {
+	api := client.BucketsAPI()
+
+	return memberships{
+		kind:    "bucket",
+		members: api.GetMembersWithID,
+		owners:  api.GetOwnersWithID,
+		add: map[string]func(ctx context.Context, resourceID string, userID string) error{
+			membershipRoleMember: func(ctx context.Context, bucketID string, userID string) error {
+				_, err := api.AddMemberWithID(ctx, bucketID, userID)
+				return err
+			},
+			membershipRoleOwner: func(ctx context.Context, bucketID string, userID string) error {
+				_, err := api.AddOwnerWithID(ctx, bucketID, userID)
+				return err
+			},
+		},
+		remove: map[string]func(ctx context.Context, resourceID string, userID string) error{
+			membershipRoleMember: api.RemoveMemberWithID,
+			membershipRoleOwner:  api.RemoveOwnerWithID,
+		},
+	}
+}
+
+// role returns the role of the user with userID in the resource with
+// resourceID, owner taking precedence, or the empty string when the user is
+// neither a member nor an owner.
+func (m memberships) role(ctx context.Context, resourceID string, userID string) (string, error) {
+	owners, err := m.owners(ctx, resourceID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if owners != nil && slices.ContainsFunc(*owners, func(owner domain.ResourceOwner) bool { return owner.Id != nil && *owner.Id == userID }) {
+		return membershipRoleOwner, nil
+	}
+
+	members, err := m.members(ctx, resourceID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if members != nil && slices.ContainsFunc(*members, func(member domain.ResourceMember) bool { return member.Id != nil && *member.Id == userID }) {
+		return membershipRoleMember, nil
+	}
+
+	return "", nil
+}
+
+// lastOwner reports whether the user with userID is the only owner of the
+// resource with resourceID.
+func (m memberships) lastOwner(ctx context.Context, resourceID string, userID string) (bool, error) {
+	owners, err := m.owners(ctx, resourceID)
+
+	if err != nil || owners == nil {
+		return false, err
+	}
+
+	return len(*owners) == 1 && (*owners)[0].Id != nil && *(*owners)[0].Id == userID, nil
+}
+
+// adopt grants the role to to the user with userID, which may already be a
+// member or an owner of the resource with resourceID, and returns its
+// previous role. An existing owner is never demoted to member, that returns
+// errOwnerDowngrade without changing anything.
+func (m memberships) adopt(ctx context.Context, resourceID string, userID string, to string) (string, error) {
+	from, err := m.role(ctx, resourceID, userID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if from == membershipRoleOwner && to == membershipRoleMember {
+		return from, errOwnerDowngrade
+	}
+
+	return from, m.setRole(ctx, resourceID, userID, from, to)
+}
+
+// setRole changes the role of the user with userID from the role from to
+// the role to. The new role is granted before the old one is revoked, so the
+// user keeps its access throughout. An empty from only grants the new role.
+func (m memberships) setRole(ctx context.Context, resourceID string, userID string, from string, to string) error {
+	if from == to {
+		return nil
+	}
+
+	if err := m.add[to](ctx, resourceID, userID); err != nil {
+		return fmt.Errorf("could not add %s %s to %s %s: %w", to, userID, m.kind, resourceID, err)
+	}
+
+	if from == "" {
+		return nil
+	}
+
+	if err := m.revoke(ctx, resourceID, userID, from); err != nil {
+		return fmt.Errorf("could not remove %s %s from %s %s: %w", from, userID, m.kind, resourceID, err)
+	}
+
+	return nil
+}
+
+// revoke removes the user with userID from the resource with resourceID,
+// where it has role. A membership that is already gone is not an error.
+func (m memberships) revoke(ctx context.Context, resourceID string, userID string, role string) error {
+	remove, ok := m.remove[role]
+
+	if !ok {
+		remove = m.remove[membershipRoleMember]
+	}
+
+	if err := remove(ctx, resourceID, userID); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// membershipID returns the id of the membership of the user with userID in
+// the resource with resourceID.
+func membershipID(resourceID string, userID string) string {
+	return resourceID + "/" + userID
+}
+
+// parseMembershipID splits a membership id into the resource and user ids.
+func parseMembershipID(id string) (string, string, error) {
+	resourceID, userID, found := strings.Cut(id, "/")
+
+	if !found || resourceID == "" || userID == "" || strings.Contains(userID, "/") {
+		return "", "", fmt.Errorf("expected an id of the form <resource_id>/<user_id>, got %q", id)
+	}
+
+	return resourceID, userID, nil
+}
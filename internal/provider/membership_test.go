@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseMembershipID(t *testing.T) {
+	tests := []struct {
+		id        string
+		expectErr bool
+	}{
+		{id: "0000000000000001/0000000000000002"},
+		{id: "0000000000000001", expectErr: true},
+		{id: "/0000000000000002", expectErr: true},
+		{id: "0000000000000001/", expectErr: true},
+		{id: "0000000000000001/0000000000000002/0000000000000003", expectErr: true},
+	}
+
+	for _, test := range tests {
+		resourceID, userID, err := parseMembershipID(test.id)
+
+		if (err != nil) != test.expectErr {
+			t.Errorf("%s: unexpected error: %v", test.id, err)
+		}
+
+		if err == nil && membershipID(resourceID, userID) != test.id {
+			t.Errorf("%s: expected the id to round trip, got %s and %s", test.id, resourceID, userID)
+		}
+	}
+}
+
+// newMembersServer serves the members and owners of a single resource
+// under prefix, with users mapping user ids to their role, and answers 404
+// for other resources like the server does. The authenticated user is
+// 0000000000000009.
+func newMembersServer(t *testing.T, prefix string, users map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/api/v2/me" {
+			_, _ = w.Write([]byte(`{"id": "0000000000000009", "name": "admin"}`))
+
+			return
+		}
+
+		rest, found := strings.CutPrefix(r.URL.Path, prefix+"/")
+		collection, id, _ := strings.Cut(rest, "/")
+		role := strings.TrimSuffix(collection, "s")
+
+		if !found || (role != membershipRoleMember && role != membershipRoleOwner) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "not found", "message": "not found"}`))
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			var added struct {
+				Id string `json:"id"`
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(&added)
+			users[added.Id] = role
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "` + added.Id + `", "name": "user", "role": "` + role + `"}`))
+		case http.MethodDelete:
+			if users[id] != role {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code": "not found", "message": "user not found"}`))
+
+				return
+			}
+
+			delete(users, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			listed := []map[string]string{}
+
+			for userID, userRole := range users {
+				if userRole == role {
+					listed = append(listed, map[string]string{"id": userID, "name": "user", "role": role})
+				}
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"users": listed})
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSetRoleGrantsBeforeRevoking(t *testing.T) {
+	ctx := context.Background()
+	users := map[string]string{"0000000000000002": membershipRoleMember}
+
+	var requests []string
+
+	members := newMembersServer(t, "/api/v2/orgs/0000000000000001", users)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v2/orgs/0000000000000001/"))
+		members.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	api := organizationMemberships(client)
+
+	if err := api.setRole(ctx, "0000000000000001", "0000000000000002", membershipRoleMember, membershipRoleOwner); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"POST owners", "DELETE members/0000000000000002"}
+
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+
+	role, err := api.role(ctx, "0000000000000001", "0000000000000002")
+
+	if err != nil || role != membershipRoleOwner {
+		t.Errorf("expected the user to be an owner, got %q %v", role, err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &notificationEndpointDataSource{}
+	_ datasource.DataSourceWithConfigure = &notificationEndpointDataSource{}
+)
+
+func NotificationEndpointDataSource() datasource.DataSource {
+	return &notificationEndpointDataSource{}
+}
+
+type notificationEndpointDataSource struct {
+	client influxdb2.Client
+}
+
+// notificationEndpointDataSourceModel describes the data source data model.
+type notificationEndpointDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	OrgID       types.String `tfsdk:"org_id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Status      types.String `tfsdk:"status"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *notificationEndpointDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_endpoint"
+}
+
+func (d *notificationEndpointDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Notification endpoint looked up by `id` or by `org_id` and `name`. Endpoint secrets are never read.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint type, for example `slack`, `pagerduty` or `http`",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint status, `active` or `inactive`",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint description",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *notificationEndpointDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// findNotificationEndpoint reads the endpoint with id, or the only endpoint
+// of orgID called name when id is empty.
+func findNotificationEndpoint(ctx context.Context, client influxdb2.Client, id string, orgID string, name string) (influxrest.NotificationEndpoint, error) {
+	if id != "" {
+		return influxrest.GetNotificationEndpoint(ctx, client, id)
+	}
+
+	endpoints, err := influxrest.ListNotificationEndpoints(ctx, client, orgID)
+
+	if err != nil {
+		return influxrest.NotificationEndpoint{}, err
+	}
+
+	return selectByName("notification endpoint", orgID, name, endpoints,
+		func(endpoint influxrest.NotificationEndpoint) string { return endpoint.Name },
+		func(endpoint influxrest.NotificationEndpoint) string { return endpoint.Id },
+	)
+}
+
+func (d *notificationEndpointDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state notificationEndpointDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	validateLookup(state.Id, state.OrgID, state.Name, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := findNotificationEndpoint(ctx, d.client, state.Id.ValueString(), state.OrgID.ValueString(), state.Name.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading notification endpoint",
+			fmt.Sprintf("Could not read notification endpoint : %s", err),
+		)
+
+		return
+	}
+
+	state.Id = types.StringValue(endpoint.Id)
+	state.OrgID = types.StringValue(endpoint.OrgID)
+	state.Name = types.StringValue(endpoint.Name)
+	state.Type = types.StringValue(endpoint.Type)
+	state.Status = types.StringValue(endpoint.Status)
+	state.Description = stringValueOrNull(&endpoint.Description)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindNotificationEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("orgID") != "0000000000000001" {
+			t.Errorf("expected the orgID filter, got %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"notificationEndpoints":[
+			{"id":"0000000000000010","orgID":"0000000000000001","name":"slack","type":"slack","status":"active","token":"secret"},
+			{"id":"0000000000000011","orgID":"0000000000000001","name":"pager","type":"pagerduty","status":"inactive"},
+			{"id":"0000000000000012","orgID":"0000000000000001","name":"pager","type":"pagerduty","status":"active"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	endpoint, err := findNotificationEndpoint(ctx, client, "", "0000000000000001", "slack")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if endpoint.Id != "0000000000000010" || endpoint.Type != "slack" || endpoint.Status != "active" {
+		t.Errorf("unexpected endpoint %+v", endpoint)
+	}
+
+	if _, err := findNotificationEndpoint(ctx, client, "", "0000000000000001", "email"); err == nil || !strings.Contains(err.Error(), `no notification endpoint named "email"`) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	_, err = findNotificationEndpoint(ctx, client, "", "0000000000000001", "pager")
+
+	if err == nil || !strings.Contains(err.Error(), "0000000000000011, 0000000000000012") {
+		t.Errorf("expected an ambiguous name error listing the candidates, got %v", err)
+	}
+}
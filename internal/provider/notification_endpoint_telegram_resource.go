@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &notificationEndpointTelegramResource{}
+var _ resource.ResourceWithImportState = &notificationEndpointTelegramResource{}
+var _ resource.ResourceWithModifyPlan = &notificationEndpointTelegramResource{}
+
+func NotificationEndpointTelegramResource() resource.Resource {
+	return &notificationEndpointTelegramResource{}
+}
+
+// notificationEndpointTelegramResource defines the resource implementation.
+type notificationEndpointTelegramResource struct {
+	providerData *providerData
+}
+
+// notificationEndpointTelegramResourceModel describes the resource data model.
+type notificationEndpointTelegramResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	OrgID   types.String `tfsdk:"org_id"`
+	Name    types.String `tfsdk:"name"`
+	Token   types.String `tfsdk:"token"`
+	Channel types.String `tfsdk:"channel"`
+	Status  types.String `tfsdk:"status"`
+
+	EffectiveLabels types.Set `tfsdk:"effective_labels"`
+}
+
+func (r *notificationEndpointTelegramResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_endpoint_telegram"
+}
+
+func (r *notificationEndpointTelegramResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Notification endpoint sending messages through a Telegram bot. " +
+			"The server never returns the bot token, so changes made to it outside of Terraform are not detected.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint name",
+				Required:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Telegram bot token, stored as a secret by the server",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"channel": schema.StringAttribute{
+				MarkdownDescription: "Id of the Telegram channel messages are sent to",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Notification endpoint status, `active` or `inactive`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("active"),
+			},
+			"effective_labels": effectiveLabelsAttribute("Labels attached to the notification endpoint, including the provider `default_labels`"),
+		},
+	}
+}
+
+func (r *notificationEndpointTelegramResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+// ModifyPlan plans effective_labels as unknown when a provider default_labels
+// entry is missing from the endpoint, and warns when the provider token
+// cannot manage notification endpoints.
+func (r *notificationEndpointTelegramResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_notification_endpoint_telegram", &resp.Diagnostics)
+	planEffectiveLabels(ctx, r.providerData, req, resp)
+}
+
+// telegramEndpointErrorAttributes lists the attributes API validation errors
+// of telegram endpoint requests can be attached to.
+var telegramEndpointErrorAttributes = map[string]bool{
+	"name":    true,
+	"token":   true,
+	"channel": true,
+	"status":  true,
+}
+
+// telegramEndpointToModel maps the server representation of an endpoint to
+// the resource model. The token is kept as configured, as the server only
+// returns a reference to the secret holding it.
+func telegramEndpointToModel(ctx context.Context, endpoint influxrest.TelegramEndpoint, model *notificationEndpointTelegramResourceModel) diag.Diagnostics {
+	model.Id = types.StringValue(endpoint.Id)
+	model.OrgID = types.StringValue(endpoint.OrgID)
+	model.Name = types.StringValue(endpoint.Name)
+	model.Channel = types.StringValue(endpoint.Channel)
+	model.Status = types.StringValue(endpoint.Status)
+
+	effectiveLabels, diags := flattenLabels(ctx, &endpoint.Labels)
+	model.EffectiveLabels = effectiveLabels
+
+	return diags
+}
+
+// saveTelegramEndpoint creates the endpoint described by model, or replaces
+// the endpoint with id when id is not empty.
+func saveTelegramEndpoint(ctx context.Context, client influxdb2.Client, id string, model notificationEndpointTelegramResourceModel) (influxrest.TelegramEndpoint, error) {
+	return influxrest.SaveTelegramEndpoint(ctx, client, id, influxrest.TelegramEndpoint{
+		Id:      id,
+		Type:    "telegram",
+		OrgID:   model.OrgID.ValueString(),
+		Name:    model.Name.ValueString(),
+		Status:  model.Status.ValueString(),
+		Token:   model.Token.ValueString(),
+		Channel: model.Channel.ValueString(),
+	})
+}
+
+// applyDefaultLabels attaches the provider default_labels missing from
+// endpoint and returns the endpoint as stored afterwards.
+func (r *notificationEndpointTelegramResource) applyDefaultLabels(ctx context.Context, endpoint influxrest.TelegramEndpoint) (influxrest.TelegramEndpoint, error) {
+	if len(r.providerData.defaultLabels) == 0 {
+		return endpoint, nil
+	}
+
+	client := r.providerData.client
+
+	err := attachDefaultLabels(ctx, client, endpoint.OrgID, r.providerData.defaultLabels, &endpoint.Labels, func(labelID string) error {
+		return addResourceLabel(ctx, client, "notificationEndpoints/"+url.PathEscape(endpoint.Id), labelID)
+	})
+
+	if err != nil {
+		return endpoint, err
+	}
+
+	return findAfterCreate(ctx, r.providerData, endpoint.Id, func(ctx context.Context) (influxrest.TelegramEndpoint, error) {
+		return influxrest.GetTelegramEndpoint(ctx, client, endpoint.Id)
+	})
+}
+
+func (r *notificationEndpointTelegramResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state notificationEndpointTelegramResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_notification_endpoint_telegram", state.Name, state.Id)
+
+	endpoint, err := saveTelegramEndpoint(ctx, r.providerData.client, "", state)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, telegramEndpointErrorAttributes,
+			"Error creating notification endpoint",
+			fmt.Sprintf("Could not create notification endpoint %s : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	r.providerData.recordCreation(endpoint.Id)
+
+	endpoint, err = r.applyDefaultLabels(ctx, endpoint)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling notification endpoint",
+			fmt.Sprintf("Notification endpoint %s was created but : %s", state.Name, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(telegramEndpointToModel(ctx, endpoint, &state)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *notificationEndpointTelegramResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state notificationEndpointTelegramResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_notification_endpoint_telegram", state.Name, state.Id)
+
+	endpoint, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (influxrest.TelegramEndpoint, error) {
+		return influxrest.GetTelegramEndpoint(ctx, r.providerData.client, state.Id.ValueString())
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading notification endpoint",
+			fmt.Sprintf("Could not read notification endpoint %s with ID %s : %s", state.Name, state.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(telegramEndpointToModel(ctx, endpoint, &state)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *notificationEndpointTelegramResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan notificationEndpointTelegramResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_notification_endpoint_telegram", plan.Name, plan.Id)
+
+	endpoint, err := saveTelegramEndpoint(ctx, r.providerData.client, plan.Id.ValueString(), plan)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, telegramEndpointErrorAttributes,
+			"Error updating notification endpoint",
+			fmt.Sprintf("Could not update notification endpoint %s with ID %s : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	endpoint, err = r.applyDefaultLabels(ctx, endpoint)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling notification endpoint",
+			fmt.Sprintf("Notification endpoint %s with ID %s was updated but : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(telegramEndpointToModel(ctx, endpoint, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *notificationEndpointTelegramResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state notificationEndpointTelegramResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_notification_endpoint_telegram", state.Name, state.Id)
+
+	err := influxrest.DeleteNotificationEndpoint(ctx, r.providerData.client, state.Id.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting notification endpoint",
+			fmt.Sprintf("Could not delete notification endpoint %s with ID %s : %s", state.Name, state.Id, err),
+		)
+	}
+}
+
+func (r *notificationEndpointTelegramResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("token"),
+		"Telegram token not imported",
+		"The server does not return the bot token. The next apply sends the token of the configuration to the server.",
+	)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+func TestSaveTelegramEndpoint(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var body influxrest.TelegramEndpoint
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unexpected request body: %s", err)
+		}
+
+		if body.Type != "telegram" || body.Token != "bot-token" || body.Channel != "-1001234" {
+			t.Errorf("unexpected request body %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "name": "pager", "type": "telegram", "status": "active", "token": "secret: 0000000000000001-token", "channel": "-1001234"}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	model := notificationEndpointTelegramResourceModel{
+		OrgID:   types.StringValue("0000000000000002"),
+		Name:    types.StringValue("pager"),
+		Token:   types.StringValue("bot-token"),
+		Channel: types.StringValue("-1001234"),
+		Status:  types.StringValue("active"),
+	}
+
+	for _, id := range []string{"", "0000000000000001"} {
+		endpoint, err := saveTelegramEndpoint(context.Background(), client, id, model)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		state := model
+		telegramEndpointToModel(context.Background(), endpoint, &state)
+
+		if state.Id.ValueString() != "0000000000000001" || state.Token.ValueString() != "bot-token" {
+			t.Errorf("expected the id to be set and the configured token kept, got %+v", state)
+		}
+	}
+
+	expected := []string{"POST /api/v2/notificationEndpoints", "PUT /api/v2/notificationEndpoints/0000000000000001"}
+
+	if len(requests) != len(expected) || requests[0] != expected[0] || requests[1] != expected[1] {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &notificationRuleDataSource{}
+	_ datasource.DataSourceWithConfigure = &notificationRuleDataSource{}
+)
+
+func NotificationRuleDataSource() datasource.DataSource {
+	return &notificationRuleDataSource{}
+}
+
+type notificationRuleDataSource struct {
+	client influxdb2.Client
+}
+
+// notificationRuleDataSourceModel describes the data source data model.
+type notificationRuleDataSourceModel struct {
+	Id          types.String      `tfsdk:"id"`
+	OrgID       types.String      `tfsdk:"org_id"`
+	Name        types.String      `tfsdk:"name"`
+	EndpointID  types.String      `tfsdk:"endpoint_id"`
+	Type        types.String      `tfsdk:"type"`
+	Every       types.String      `tfsdk:"every"`
+	Status      types.String      `tfsdk:"status"`
+	Description types.String      `tfsdk:"description"`
+	StatusRules []statusRuleModel `tfsdk:"status_rules"`
+	TagRules    []tagRuleModel    `tfsdk:"tag_rules"`
+}
+
+type statusRuleModel struct {
+	CurrentLevel  types.String `tfsdk:"current_level"`
+	PreviousLevel types.String `tfsdk:"previous_level"`
+}
+
+type tagRuleModel struct {
+	Key      types.String `tfsdk:"key"`
+	Value    types.String `tfsdk:"value"`
+	Operator types.String `tfsdk:"operator"`
+}
+
+func (d *notificationRuleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_rule"
+}
+
+func (d *notificationRuleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Notification rule looked up by `id` or by `org_id` and `name`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Notification rule id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Notification rule name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the notification endpoint the rule sends to",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Notification rule type, matching the endpoint type",
+				Computed:            true,
+			},
+			"every": schema.StringAttribute{
+				MarkdownDescription: "Interval the rule runs at",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Notification rule status, `active` or `inactive`",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Notification rule description",
+				Computed:            true,
+			},
+			"status_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Check status transitions that trigger the rule",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"current_level": schema.StringAttribute{
+							MarkdownDescription: "Current check level",
+							Computed:            true,
+						},
+						"previous_level": schema.StringAttribute{
+							MarkdownDescription: "Previous check level, null when any level matches",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"tag_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Tags the check statuses must match",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Tag key",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Tag value",
+							Computed:            true,
+						},
+						"operator": schema.StringAttribute{
+							MarkdownDescription: "Comparison operator",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *notificationRuleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// findNotificationRule reads the rule with id, or the only rule of orgID
+// called name when id is empty.
+func findNotificationRule(ctx context.Context, client influxdb2.Client, id string, orgID string, name string) (influxrest.NotificationRule, error) {
+	if id != "" {
+		return influxrest.GetNotificationRule(ctx, client, id)
+	}
+
+	rules, err := influxrest.ListNotificationRules(ctx, client, orgID)
+
+	if err != nil {
+		return influxrest.NotificationRule{}, err
+	}
+
+	return selectByName("notification rule", orgID, name, rules,
+		func(rule influxrest.NotificationRule) string { return rule.Name },
+		func(rule influxrest.NotificationRule) string { return rule.Id },
+	)
+}
+
+// notificationRuleToModel maps rule to the data source model.
+func notificationRuleToModel(rule influxrest.NotificationRule, state *notificationRuleDataSourceModel) {
+	state.Id = types.StringValue(rule.Id)
+	state.OrgID = types.StringValue(rule.OrgID)
+	state.Name = types.StringValue(rule.Name)
+	state.EndpointID = types.StringValue(rule.EndpointID)
+	state.Type = types.StringValue(rule.Type)
+	state.Every = stringValueOrNull(&rule.Every)
+	state.Status = types.StringValue(rule.Status)
+	state.Description = stringValueOrNull(&rule.Description)
+
+	state.StatusRules = []statusRuleModel{}
+
+	for _, statusRule := range rule.StatusRules {
+		state.StatusRules = append(state.StatusRules, statusRuleModel{
+			CurrentLevel:  types.StringValue(statusRule.CurrentLevel),
+			PreviousLevel: stringValueOrNull(&statusRule.PreviousLevel),
+		})
+	}
+
+	state.TagRules = []tagRuleModel{}
+
+	for _, tagRule := range rule.TagRules {
+		state.TagRules = append(state.TagRules, tagRuleModel{
+			Key:      types.StringValue(tagRule.Key),
+			Value:    types.StringValue(tagRule.Value),
+			Operator: types.StringValue(tagRule.Operator),
+		})
+	}
+}
+
+func (d *notificationRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state notificationRuleDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	validateLookup(state.Id, state.OrgID, state.Name, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := findNotificationRule(ctx, d.client, state.Id.ValueString(), state.OrgID.ValueString(), state.Name.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading notification rule",
+			fmt.Sprintf("Could not read notification rule : %s", err),
+		)
+
+		return
+	}
+
+	notificationRuleToModel(rule, &state)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindNotificationRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"notificationRules":[
+			{"id":"0000000000000030","orgID":"0000000000000001","name":"critical","endpointID":"0000000000000010","type":"slack","every":"1m","status":"active",
+			 "statusRules":[{"currentLevel":"CRIT","previousLevel":"OK"},{"currentLevel":"WARN"}],
+			 "tagRules":[{"key":"env","value":"prod","operator":"equal"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	rule, err := findNotificationRule(ctx, client, "", "0000000000000001", "critical")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state notificationRuleDataSourceModel
+	notificationRuleToModel(rule, &state)
+
+	if state.EndpointID.ValueString() != "0000000000000010" || state.Every.ValueString() != "1m" {
+		t.Errorf("unexpected rule %+v", state)
+	}
+
+	if len(state.StatusRules) != 2 || state.StatusRules[0].PreviousLevel.ValueString() != "OK" || !state.StatusRules[1].PreviousLevel.IsNull() {
+		t.Errorf("unexpected status rules %+v", state.StatusRules)
+	}
+
+	if len(state.TagRules) != 1 || state.TagRules[0].Operator.ValueString() != "equal" {
+		t.Errorf("unexpected tag rules %+v", state.TagRules)
+	}
+
+	if _, err := findNotificationRule(ctx, client, "", "0000000000000001", "missing"); err == nil || !strings.Contains(err.Error(), "organization 0000000000000001") {
+		t.Errorf("expected a not found error naming the organization, got %v", err)
+	}
+}
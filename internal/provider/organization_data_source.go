@@ -2,13 +2,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -45,9 +49,9 @@ func (d *organizationDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Organization name",
-				Required:            true,
-				Optional:            false,
+				MarkdownDescription: "Organization name. When omitted, the only organization visible to the token is used.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Organizatin id",
@@ -79,21 +83,64 @@ func (d *organizationDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(influxdb2.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *influxdb2.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+}
+
+// findOrganization returns the organization called name or, when name is
+// null, the only organization visible to the token.
+func findOrganization(ctx context.Context, client influxdb2.Client, name types.String) (*domain.Organization, error) {
+	var params domain.GetOrgsParams
+
+	if !name.IsNull() {
+		params.Org = name.ValueStringPointer()
+	}
+
+	organizations, err := influxrest.ListOrganizations(ctx, client, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !name.IsNull() {
+		for i := range organizations {
+			if organizations[i].Name == name.ValueString() {
+				return &organizations[i], nil
+			}
+		}
+
+		return nil, errors.New("organization not found")
+	}
+
+	switch len(organizations) {
+	case 1:
+		return &organizations[0], nil
+	case 0:
+		return nil, errors.New("the token cannot see any organization")
+	default:
+		names := make([]string, 0, len(organizations))
+
+		for _, organization := range organizations {
+			names = append(names, organization.Name)
+		}
+
+		return nil, fmt.Errorf("the token can see %d organizations, set name to one of: %s", len(organizations), strings.Join(names, ", "))
+	}
 }
 
 func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
 	var state OrganizationDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -103,17 +150,22 @@ func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	organization, err := d.client.OrganizationsAPI().FindOrganizationByName(context.Background(), state.Name.ValueString())
+	organization, err := findOrganization(ctx, d.client, state.Name)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading organization",
-			fmt.Sprintf("Could not read organization %s : %s", state.Name, err),
-		)
+		detail := fmt.Sprintf("Could not read organization %s : %s", state.Name, err)
+
+		if state.Name.IsNull() {
+			detail = fmt.Sprintf("Could not find the organization of the token : %s", err)
+		}
+
+		addAPIError(ctx, &resp.Diagnostics, "Error reading organization", detail)
 
 		return
 	}
 
+	state.Name = types.StringValue(organization.Name)
+
 	state.Id = types.StringPointerValue(organization.Id)
 
 	state.Description = types.StringPointerValue(organization.Description)
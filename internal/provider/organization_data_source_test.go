@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFindOrganizationWithoutName(t *testing.T) {
+	for _, test := range []struct {
+		total int
+		err   string
+	}{
+		{total: 1},
+		{total: 0, err: "cannot see any organization"},
+		{total: 3, err: "set name to one of: item-0, item-1, item-2"},
+	} {
+		requests := 0
+		server := newPagedServer(t, "/api/v2/orgs", "orgs", test.total, &requests)
+		client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+
+		organization, err := findOrganization(context.Background(), client, types.StringNull())
+
+		client.Close()
+
+		if test.err == "" {
+			if err != nil || organization.Name != "item-0" {
+				t.Errorf("expected the only organization, got %v %v", organization, err)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected an error containing %q, got %v", test.err, err)
+		}
+	}
+}
+
+// TestAccOrganizationDataSourceSpecialNames creates organizations and buckets
+// whose names need encoding in request URLs, and looks the organizations up
+// by name.
+func TestAccOrganizationDataSourceSpecialNames(t *testing.T) {
+	suffix := acctest.RandString(6)
+	names := []string{
+		"tf acc spaces " + suffix,
+		"tf-acc/slash/" + suffix,
+		"tf-acc 100% " + suffix,
+		"tf-acc-指标-" + suffix,
+		"métricas/prod " + suffix,
+	}
+
+	config := testAccProviderConfig()
+
+	var checks []resourcetest.TestCheckFunc
+
+	for i, name := range names {
+		config += fmt.Sprintf(`
+resource "influxdbv2_organization" "name_%[1]d" {
+  name = %[2]q
+}
+
+data "influxdbv2_organization" "name_%[1]d" {
+  name = influxdbv2_organization.name_%[1]d.name
+}
+
+resource "influxdbv2_bucket" "name_%[1]d" {
+  name   = %[2]q
+  org_id = influxdbv2_organization.name_%[1]d.id
+}
+`, i, name)
+
+		checks = append(checks,
+			resourcetest.TestCheckResourceAttrPair(fmt.Sprintf("data.influxdbv2_organization.name_%d", i), "id", fmt.Sprintf("influxdbv2_organization.name_%d", i), "id"),
+			resourcetest.TestCheckResourceAttr(fmt.Sprintf("data.influxdbv2_organization.name_%d", i), "name", name),
+			resourcetest.TestCheckResourceAttr(fmt.Sprintf("influxdbv2_bucket.name_%d", i), "name", name),
+		)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config,
+				Check:  resourcetest.ComposeAggregateTestCheckFunc(checks...),
+			},
+			{
+				// Reading the resources back by id and name plans no change.
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &organizationMemberResource{}
+var _ resource.ResourceWithImportState = &organizationMemberResource{}
+var _ resource.ResourceWithValidateConfig = &organizationMemberResource{}
+var _ resource.ResourceWithModifyPlan = &organizationMemberResource{}
+
+func OrganizationMemberResource() resource.Resource {
+	return &organizationMemberResource{}
+}
+
+// organizationMemberResource defines the resource implementation.
+type organizationMemberResource struct {
+	providerData *providerData
+}
+
+// organizationMemberResourceModel describes the resource data model.
+type organizationMemberResourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	OrgID  types.String `tfsdk:"org_id"`
+	UserID types.String `tfsdk:"user_id"`
+	Role   types.String `tfsdk:"role"`
+}
+
+func (r *organizationMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_member"
+}
+
+func (r *organizationMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Membership of a user in an organization. Import with `<org_id>/<user_id>`. " +
+			"Do not also manage the same user with `influxdbv2_organization_owner`, `role` manages the ownership as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Membership id, `<org_id>/<user_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the member",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role of the user, `member` or `owner`. Changing it grants the new role before revoking the old one. " +
+					"Creating a `member` for a user that already is an owner fails instead of revoking the ownership.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(membershipRoleMember),
+			},
+		},
+	}
+}
+
+func (r *organizationMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *organizationMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var role types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+
+	if resp.Diagnostics.HasError() || role.IsNull() || role.IsUnknown() {
+		return
+	}
+
+	if err := validateOneOf("role", role.ValueString(), membershipRoles); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Invalid membership role", err.Error()+".")
+	}
+}
+
+// ModifyPlan warns when the provider token cannot manage the members of organizations.
+func (r *organizationMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_organization_member", &resp.Diagnostics)
+}
+
+func (r *organizationMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(membershipID(state.OrgID.ValueString(), state.UserID.ValueString()))
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_member", state.UserID, state.Id)
+
+	role, err := organizationMemberships(r.providerData.client).adopt(ctx, state.OrgID.ValueString(), state.UserID.ValueString(), state.Role.ValueString())
+
+	if errors.Is(err, errOwnerDowngrade) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Refusing to demote an existing owner",
+			fmt.Sprintf("User %s is an owner of organization %s. Set role = \"owner\" to manage the existing ownership, "+
+				"or remove the ownership before adding the user as a member.", state.UserID, state.OrgID),
+		)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error adding organization member",
+			fmt.Sprintf("Could not add user %s to organization %s : %s", state.UserID, state.OrgID, err),
+		)
+
+		return
+	}
+
+	if role != "" {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing membership",
+			fmt.Sprintf("User %s already was %s of organization %s, the membership is now managed by this resource.", state.UserID, role, state.OrgID),
+		)
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_member", state.UserID, state.Id)
+
+	role, err := organizationMemberships(r.providerData.client).role(ctx, state.OrgID.ValueString(), state.UserID.ValueString())
+
+	if isNotFound(err) || (err == nil && role == "") {
+		tflog.Warn(ctx, "organization membership not found, removing it from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading organization members",
+			fmt.Sprintf("Could not read the members of organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	state.Role = types.StringValue(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan, state organizationMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_member", plan.UserID, plan.Id)
+
+	err := organizationMemberships(r.providerData.client).setRole(ctx, plan.OrgID.ValueString(), plan.UserID.ValueString(), state.Role.ValueString(), plan.Role.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error changing organization member role",
+			fmt.Sprintf("Could not change the role of user %s in organization %s : %s", plan.UserID, plan.OrgID, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *organizationMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_member", state.UserID, state.Id)
+
+	err := organizationMemberships(r.providerData.client).revoke(ctx, state.OrgID.ValueString(), state.UserID.ValueString(), state.Role.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error removing organization member",
+			fmt.Sprintf("Could not remove user %s from organization %s : %s", state.UserID, state.OrgID, err),
+		)
+	}
+}
+
+func (r *organizationMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, userID, err := parseMembershipID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error()+".")
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func organizationMemberStateFor(t *testing.T, model organizationMemberResourceModel) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&organizationMemberResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return state
+}
+
+func TestOrganizationMemberLifecycle(t *testing.T) {
+	ctx := context.Background()
+	members := map[string]string{"0000000000000003": membershipRoleMember}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", members).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &organizationMemberResource{providerData: data}
+
+	for _, userID := range []string{"0000000000000002", "0000000000000003"} {
+		plan := organizationMemberStateFor(t, organizationMemberResourceModel{
+			Id:     types.StringUnknown(),
+			OrgID:  types.StringValue("0000000000000001"),
+			UserID: types.StringValue(userID),
+			Role:   types.StringValue(membershipRoleMember),
+		})
+		createResp := resource.CreateResponse{State: plan}
+
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &createResp)
+
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+		}
+
+		if adopted := createResp.Diagnostics.WarningsCount() == 1; adopted != (userID == "0000000000000003") {
+			t.Errorf("%s: unexpected diagnostics: %v", userID, createResp.Diagnostics)
+		}
+
+		if members[userID] != membershipRoleMember {
+			t.Errorf("expected user %s to be a member", userID)
+		}
+
+		readResp := resource.ReadResponse{State: createResp.State}
+		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+
+		if readResp.Diagnostics.HasError() || !readResp.State.Raw.Equal(createResp.State.Raw) {
+			t.Errorf("expected the read to leave the state unchanged, got %v %s", readResp.Diagnostics, readResp.State.Raw)
+		}
+
+		deleteResp := resource.DeleteResponse{State: createResp.State}
+		r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+
+		if deleteResp.Diagnostics.HasError() || members[userID] != "" {
+			t.Errorf("expected user %s to be removed, got %v", userID, deleteResp.Diagnostics)
+		}
+
+		goneResp := resource.ReadResponse{State: createResp.State}
+		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &goneResp)
+
+		if goneResp.Diagnostics.HasError() || !goneResp.State.Raw.IsNull() {
+			t.Errorf("expected a revoked membership to be removed from the state, got %v %s", goneResp.Diagnostics, goneResp.State.Raw)
+		}
+	}
+}
+
+func TestOrganizationMemberCreateKeepsOwner(t *testing.T) {
+	ctx := context.Background()
+	members := map[string]string{"0000000000000002": membershipRoleOwner}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", members).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	plan := organizationMemberStateFor(t, organizationMemberResourceModel{
+		Id:     types.StringUnknown(),
+		OrgID:  types.StringValue("0000000000000001"),
+		UserID: types.StringValue("0000000000000002"),
+		Role:   types.StringValue(membershipRoleMember),
+	})
+	createResp := resource.CreateResponse{State: plan}
+
+	(&organizationMemberResource{providerData: data}).Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &createResp)
+
+	if !createResp.Diagnostics.HasError() {
+		t.Errorf("expected adopting an owner as a member to fail")
+	}
+
+	if members["0000000000000002"] != membershipRoleOwner {
+		t.Errorf("expected the user to stay an owner, got %q", members["0000000000000002"])
+	}
+}
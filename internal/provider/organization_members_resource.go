@@ -0,0 +1,404 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &organizationMembersResource{}
+var _ resource.ResourceWithImportState = &organizationMembersResource{}
+var _ resource.ResourceWithModifyPlan = &organizationMembersResource{}
+
+func OrganizationMembersResource() resource.Resource {
+	return &organizationMembersResource{}
+}
+
+// organizationMembersResource defines the resource implementation.
+type organizationMembersResource struct {
+	providerData *providerData
+}
+
+// organizationMembersResourceModel describes the resource data model.
+type organizationMembersResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	OrgID          types.String `tfsdk:"org_id"`
+	UserIDs        types.Set    `tfsdk:"user_ids"`
+	AllowUnmanaged types.Bool   `tfsdk:"allow_unmanaged"`
+}
+
+func (r *organizationMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_members"
+}
+
+func (r *organizationMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritative list of the members of an organization. Members added outside Terraform are removed " +
+			"unless `allow_unmanaged` is set. Do not combine with `influxdbv2_organization_member` for the same organization. " +
+			"Import with the organization id.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_ids": schema.SetAttribute{
+				MarkdownDescription: "Ids of the members",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"allow_unmanaged": schema.BoolAttribute{
+				MarkdownDescription: "Only add the listed members and keep members added outside Terraform. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *organizationMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+// ModifyPlan warns when the plan removes the user the provider is
+// authenticated as, which may lock it out of the organization. On create the
+// removed members are the current members of the organization that are not
+// listed, unless allow_unmanaged keeps them. It also warns when the provider
+// token cannot manage the members of organizations.
+func (r *organizationMembersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_organization_members", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan organizationMembersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.UserIDs.IsUnknown() {
+		return
+	}
+
+	var planned, current []string
+
+	resp.Diagnostics.Append(plan.UserIDs.ElementsAs(ctx, &planned, false)...)
+
+	if req.State.Raw.IsNull() {
+		if plan.OrgID.IsUnknown() || plan.AllowUnmanaged.IsUnknown() || plan.AllowUnmanaged.ValueBool() {
+			return
+		}
+
+		members, err := organizationMemberIDs(ctx, r.providerData.client, plan.OrgID.ValueString())
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Could not list the members of organization %s: %v", plan.OrgID, err))
+
+			return
+		}
+
+		current = members
+	} else {
+		var state organizationMembersResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(state.UserIDs.ElementsAs(ctx, &current, false)...)
+	}
+
+	removed := idsNotIn(current, planned)
+
+	if resp.Diagnostics.HasError() || len(removed) == 0 {
+		return
+	}
+
+	me, err := r.providerData.client.UsersAPI().Me(ctx)
+
+	if err != nil || me.Id == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Could not look up the authenticated user: %v", err))
+
+		return
+	}
+
+	if slices.Contains(removed, *me.Id) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("user_ids"),
+			"Removing the authenticated user",
+			fmt.Sprintf("The plan removes user %s, which the provider is authenticated as, from organization %s. "+
+				"The provider may lose access to the organization.", *me.Id, plan.OrgID),
+		)
+	}
+}
+
+// organizationMemberIDs returns the ids of the members of the organization
+// with orgID.
+func organizationMemberIDs(ctx context.Context, client influxdb2.Client, orgID string) ([]string, error) {
+	members, err := client.OrganizationsAPI().GetMembersWithID(ctx, orgID)
+
+	if err != nil || members == nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(*members))
+
+	for _, member := range *members {
+		if member.Id != nil {
+			ids = append(ids, *member.Id)
+		}
+	}
+
+	return ids, nil
+}
+
+// idsNotIn returns the ids of ids that are not in other.
+func idsNotIn(ids []string, other []string) []string {
+	var result []string
+
+	for _, id := range ids {
+		if !slices.Contains(other, id) {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// reconcileOrganizationMembers adds the users of userIDs that are not members
+// of the organization with orgID, and removes the members not in userIDs
+// when removeUnmanaged is set.
+func reconcileOrganizationMembers(ctx context.Context, client influxdb2.Client, orgID string, userIDs []string, removeUnmanaged bool) error {
+	current, err := organizationMemberIDs(ctx, client, orgID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, id := range idsNotIn(userIDs, current) {
+		if _, err := client.OrganizationsAPI().AddMemberWithID(ctx, orgID, id); err != nil {
+			return fmt.Errorf("could not add user %s: %w", id, err)
+		}
+	}
+
+	if !removeUnmanaged {
+		return nil
+	}
+
+	for _, id := range idsNotIn(current, userIDs) {
+		if err := client.OrganizationsAPI().RemoveMemberWithID(ctx, orgID, id); err != nil && !isNotFound(err) {
+			return fmt.Errorf("could not remove user %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// saveOrganizationMembers reconciles the members of the organization with
+// model.
+func saveOrganizationMembers(ctx context.Context, client influxdb2.Client, model *organizationMembersResourceModel) error {
+	var userIDs []string
+
+	if diags := model.UserIDs.ElementsAs(ctx, &userIDs, false); diags.HasError() {
+		return fmt.Errorf("could not read user_ids")
+	}
+
+	model.Id = model.OrgID
+
+	return reconcileOrganizationMembers(ctx, client, model.OrgID.ValueString(), userIDs, !model.AllowUnmanaged.ValueBool())
+}
+
+func (r *organizationMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMembersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_members", types.StringNull(), state.OrgID)
+
+	if err := saveOrganizationMembers(ctx, r.providerData.client, &state); err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error setting organization members",
+			fmt.Sprintf("Could not set the members of organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMembersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_members", types.StringNull(), state.OrgID)
+
+	current, err := organizationMemberIDs(ctx, r.providerData.client, state.OrgID.ValueString())
+
+	if isNotFound(err) {
+		tflog.Warn(ctx, "organization not found, removing its members from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading organization members",
+			fmt.Sprintf("Could not read the members of organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	// Members added outside Terraform are left out when they are allowed,
+	// so they do not show as removals.
+	if state.AllowUnmanaged.ValueBool() {
+		var managed []string
+
+		resp.Diagnostics.Append(state.UserIDs.ElementsAs(ctx, &managed, false)...)
+
+		current = slices.DeleteFunc(current, func(id string) bool {
+			return !slices.Contains(managed, id)
+		})
+	}
+
+	if current == nil {
+		current = []string{}
+	}
+
+	userIDs, diags := types.SetValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+
+	state.Id = state.OrgID
+	state.UserIDs = userIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan organizationMembersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_members", types.StringNull(), plan.OrgID)
+
+	if err := saveOrganizationMembers(ctx, r.providerData.client, &plan); err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error setting organization members",
+			fmt.Sprintf("Could not set the members of organization %s : %s", plan.OrgID, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *organizationMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationMembersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_members", types.StringNull(), state.OrgID)
+
+	var userIDs []string
+
+	resp.Diagnostics.Append(state.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+
+	var failed []string
+
+	for _, id := range userIDs {
+		err := r.providerData.client.OrganizationsAPI().RemoveMemberWithID(ctx, state.OrgID.ValueString(), id)
+
+		if err != nil && !isNotFound(err) {
+			failed = append(failed, fmt.Sprintf("%s: %s", id, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error removing organization members",
+			fmt.Sprintf("Could not remove members from organization %s : %s", state.OrgID, strings.Join(failed, "; ")),
+		)
+	}
+}
+
+func (r *organizationMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_unmanaged"), false)...)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func organizationMembersStateFor(t *testing.T, userIDs []string, allowUnmanaged bool) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&organizationMembersResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	set, _ := types.SetValueFrom(ctx, types.StringType, userIDs)
+
+	diags := state.Set(ctx, &organizationMembersResourceModel{
+		Id:             types.StringValue("0000000000000001"),
+		OrgID:          types.StringValue("0000000000000001"),
+		UserIDs:        set,
+		AllowUnmanaged: types.BoolValue(allowUnmanaged),
+	})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return state
+}
+
+func TestReconcileOrganizationMembers(t *testing.T) {
+	for _, removeUnmanaged := range []bool{true, false} {
+		members := map[string]string{"0000000000000002": membershipRoleMember, "0000000000000003": membershipRoleMember}
+
+		client := newInfluxClient(newMembersServer(t, "/api/v2/orgs/0000000000000001", members).URL, "token", http.DefaultTransport)
+		defer client.Close()
+
+		err := reconcileOrganizationMembers(context.Background(), client, "0000000000000001", []string{"0000000000000003", "0000000000000004"}, removeUnmanaged)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if members["0000000000000003"] == "" || members["0000000000000004"] == "" || (members["0000000000000002"] == "") != removeUnmanaged {
+			t.Errorf("unexpected members with removeUnmanaged %t: %v", removeUnmanaged, members)
+		}
+	}
+}
+
+func TestOrganizationMembersRead(t *testing.T) {
+	ctx := context.Background()
+	members := map[string]string{"0000000000000002": membershipRoleMember, "0000000000000003": membershipRoleMember}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", members).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &organizationMembersResource{providerData: data}
+
+	for _, test := range []struct {
+		allowUnmanaged bool
+		expected       []string
+	}{
+		{allowUnmanaged: false, expected: []string{"0000000000000002", "0000000000000003"}},
+		{allowUnmanaged: true, expected: []string{"0000000000000003"}},
+	} {
+		state := organizationMembersStateFor(t, []string{"0000000000000003", "0000000000000004"}, test.allowUnmanaged)
+		resp := resource.ReadResponse{State: state}
+
+		r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var userIDs types.Set
+		resp.State.GetAttribute(ctx, path.Root("user_ids"), &userIDs)
+
+		expected, _ := types.SetValueFrom(ctx, types.StringType, test.expected)
+
+		if !userIDs.Equal(expected) {
+			t.Errorf("allow_unmanaged %t: expected user_ids %s, got %s", test.allowUnmanaged, expected, userIDs)
+		}
+	}
+}
+
+func TestOrganizationMembersModifyPlanWarnsAboutAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", map[string]string{}).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &organizationMembersResource{providerData: data}
+	state := organizationMembersStateFor(t, []string{"0000000000000002", "0000000000000009"}, false)
+
+	for _, test := range []struct {
+		planned  []string
+		expected int
+	}{
+		{planned: []string{"0000000000000009"}, expected: 0},
+		{planned: []string{"0000000000000002"}, expected: 1},
+	} {
+		planned := organizationMembersStateFor(t, test.planned, false)
+		plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != test.expected {
+			t.Errorf("expected %d warnings when planning %v, got %v", test.expected, test.planned, resp.Diagnostics)
+		}
+	}
+}
+
+func TestOrganizationMembersModifyPlanWarnsOnCreate(t *testing.T) {
+	ctx := context.Background()
+
+	members := map[string]string{"0000000000000009": membershipRoleMember}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", members).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &organizationMembersResource{providerData: data}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	for _, test := range []struct {
+		allowUnmanaged bool
+		expected       int
+	}{
+		{allowUnmanaged: false, expected: 1},
+		{allowUnmanaged: true, expected: 0},
+	} {
+		planned := organizationMembersStateFor(t, []string{"0000000000000002"}, test.allowUnmanaged)
+		plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != test.expected {
+			t.Errorf("allow_unmanaged %t: expected %d warnings, got %v", test.allowUnmanaged, test.expected, resp.Diagnostics)
+		}
+	}
+}
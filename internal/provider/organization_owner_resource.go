@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &organizationOwnerResource{}
+var _ resource.ResourceWithImportState = &organizationOwnerResource{}
+var _ resource.ResourceWithModifyPlan = &organizationOwnerResource{}
+
+func OrganizationOwnerResource() resource.Resource {
+	return &organizationOwnerResource{}
+}
+
+// organizationOwnerResource defines the resource implementation.
+type organizationOwnerResource struct {
+	providerData *providerData
+}
+
+// organizationOwnerResourceModel describes the resource data model.
+type organizationOwnerResourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	OrgID  types.String `tfsdk:"org_id"`
+	UserID types.String `tfsdk:"user_id"`
+}
+
+func (r *organizationOwnerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_owner"
+}
+
+func (r *organizationOwnerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ownership of an organization by a user. Import with `<org_id>/<user_id>`. " +
+			"Do not also manage the same user with `influxdbv2_organization_member`, whose `role` manages the ownership as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Ownership id, `<org_id>/<user_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the owner",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationOwnerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+// ModifyPlan warns when the provider token cannot manage the owners of organizations.
+func (r *organizationOwnerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_organization_owner", &resp.Diagnostics)
+}
+
+func (r *organizationOwnerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationOwnerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(membershipID(state.OrgID.ValueString(), state.UserID.ValueString()))
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_owner", state.UserID, state.Id)
+
+	api := organizationMemberships(r.providerData.client)
+	role, err := api.role(ctx, state.OrgID.ValueString(), state.UserID.ValueString())
+
+	// An existing membership is kept, only the ownership is managed.
+	if err == nil && role != membershipRoleOwner {
+		err = api.setRole(ctx, state.OrgID.ValueString(), state.UserID.ValueString(), "", membershipRoleOwner)
+	} else if err == nil {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing ownership",
+			fmt.Sprintf("User %s already was an owner of organization %s, the ownership is now managed by this resource.", state.UserID, state.OrgID),
+		)
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error adding organization owner",
+			fmt.Sprintf("Could not add owner %s to organization %s : %s", state.UserID, state.OrgID, err),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationOwnerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationOwnerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_owner", state.UserID, state.Id)
+
+	role, err := organizationMemberships(r.providerData.client).role(ctx, state.OrgID.ValueString(), state.UserID.ValueString())
+
+	if isNotFound(err) || (err == nil && role != membershipRoleOwner) {
+		tflog.Warn(ctx, "organization ownership not found, removing it from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading organization owners",
+			fmt.Sprintf("Could not read the owners of organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes, every attribute requires replacement.
+func (r *organizationOwnerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan organizationOwnerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *organizationOwnerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state organizationOwnerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_organization_owner", state.UserID, state.Id)
+
+	api := organizationMemberships(r.providerData.client)
+	err := api.revoke(ctx, state.OrgID.ValueString(), state.UserID.ValueString(), membershipRoleOwner)
+
+	if err == nil {
+		return
+	}
+
+	if last, _ := api.lastOwner(ctx, state.OrgID.ValueString(), state.UserID.ValueString()); last {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Cannot remove the last organization owner",
+			fmt.Sprintf("User %s is the only owner of organization %s. Add another owner before removing this one : %s", state.UserID, state.OrgID, err),
+		)
+
+		return
+	}
+
+	addAPIError(ctx, &resp.Diagnostics,
+		"Error removing organization owner",
+		fmt.Sprintf("Could not remove owner %s from organization %s : %s", state.UserID, state.OrgID, err),
+	)
+}
+
+func (r *organizationOwnerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, userID, err := parseMembershipID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error()+".")
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
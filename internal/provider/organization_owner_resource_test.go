@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func organizationOwnerStateFor(t *testing.T, model organizationOwnerResourceModel) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&organizationOwnerResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return state
+}
+
+func TestOrganizationOwnerLifecycle(t *testing.T) {
+	ctx := context.Background()
+	owners := map[string]string{"0000000000000003": membershipRoleOwner}
+
+	data := newProviderData(newMembersServer(t, "/api/v2/orgs/0000000000000001", owners).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &organizationOwnerResource{providerData: data}
+
+	plan := organizationOwnerStateFor(t, organizationOwnerResourceModel{
+		Id:     types.StringUnknown(),
+		OrgID:  types.StringValue("0000000000000001"),
+		UserID: types.StringValue("0000000000000002"),
+	})
+	createResp := resource.CreateResponse{State: plan}
+
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &createResp)
+
+	if createResp.Diagnostics.HasError() || owners["0000000000000002"] != membershipRoleOwner {
+		t.Fatalf("expected user 0000000000000002 to be an owner, got %v", createResp.Diagnostics)
+	}
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+
+	if deleteResp.Diagnostics.HasError() || owners["0000000000000002"] != "" {
+		t.Errorf("expected user 0000000000000002 to be removed, got %v", deleteResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected a revoked ownership to be removed from the state, got %v %s", readResp.Diagnostics, readResp.State.Raw)
+	}
+}
+
+func TestOrganizationOwnerDeleteLastOwner(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": "invalid", "message": "cannot remove the last owner"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"users": [{"id": "0000000000000002", "name": "admin", "role": "owner"}]}`))
+	}))
+	defer server.Close()
+
+	data := newProviderData(server.URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	state := organizationOwnerStateFor(t, organizationOwnerResourceModel{
+		Id:     types.StringValue("0000000000000001/0000000000000002"),
+		OrgID:  types.StringValue("0000000000000001"),
+		UserID: types.StringValue("0000000000000002"),
+	})
+	resp := resource.DeleteResponse{State: state}
+
+	(&organizationOwnerResource{providerData: data}).Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Add another owner") {
+		t.Errorf("expected the last owner error, got %v", resp.Diagnostics)
+	}
+}
@@ -6,19 +6,26 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &organizationResource{}
 var _ resource.ResourceWithImportState = &organizationResource{}
+var _ resource.ResourceWithModifyPlan = &organizationResource{}
 
 func OrganizationResource() resource.Resource {
 	return &organizationResource{}
@@ -26,17 +33,21 @@ func OrganizationResource() resource.Resource {
 
 // organizationResource defines the resource implementation.
 type organizationResource struct {
-	client influxdb2.Client
+	providerData *providerData
 }
 
 // organizationResourceModel describes the resource data model.
 type organizationResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Id          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"description"`
-	Status      types.String `tfsdk:"status"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	UpdatedAt   types.String `tfsdk:"updated_at"`
+	Name           types.String   `tfsdk:"name"`
+	Id             types.String   `tfsdk:"id"`
+	Description    types.String   `tfsdk:"description"`
+	Status         types.String   `tfsdk:"status"`
+	CreatedAt      types.String   `tfsdk:"created_at"`
+	UpdatedAt      types.String   `tfsdk:"updated_at"`
+	Token          types.String   `tfsdk:"token"`
+	RetainOnDelete types.Bool     `tfsdk:"retain_on_delete"`
+	CascadeDelete  types.Bool     `tfsdk:"cascade_delete"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,6 +73,9 @@ func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRe
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Organizatin id",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "Organizatin description",
@@ -70,11 +84,40 @@ func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRe
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Organizatin creation date",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "API token used for this resource instead of the provider token, " +
+					"for example a token scoped to the organization",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"updated_at": schema.StringAttribute{
 				MarkdownDescription: "Organizatin update date",
 				Computed:            true,
 			},
+			"retain_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Leave the organization and everything it contains in place when the resource " +
+					"is destroyed, only removing it from the Terraform state.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"cascade_delete": schema.BoolAttribute{
+				MarkdownDescription: "Allow destroying the organization while it still contains buckets or tasks, " +
+					"which are destroyed with it. Without it, destroying a non-empty organization fails and lists its contents.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -84,21 +127,95 @@ func (r *organizationResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(influxdb2.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *influxdb2.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
+}
+
+// ModifyPlan warns when the provider token cannot manage organizations.
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_organization", &resp.Diagnostics)
+}
+
+// organizationErrorAttributes lists the attributes API validation errors of
+// organization requests can be attached to.
+var organizationErrorAttributes = map[string]bool{
+	"name":        true,
+	"description": true,
+}
+
+// orgToModel maps the server representation of an organization to the
+// resource model, leaving the provider only attributes untouched.
+func orgToModel(organization *domain.Organization, model *organizationResourceModel) {
+	model.Id = types.StringPointerValue(organization.Id)
+
+	model.Name = types.StringValue(organization.Name)
+
+	model.Description = stringValueOrNull(organization.Description)
+
+	model.Status = types.StringPointerValue((*string)(organization.Status))
+
+	model.CreatedAt = timeValue(organization.CreatedAt)
+
+	model.UpdatedAt = timeValue(organization.UpdatedAt)
+}
+
+// organizationContents describes the user buckets and tasks of the
+// organization with orgID, which deleting the organization destroys.
+func organizationContents(ctx context.Context, client influxdb2.Client, orgID string) ([]string, error) {
+	buckets, err := influxrest.ListBuckets(ctx, client, domain.GetBucketsParams{OrgID: &orgID})
+
+	if err != nil {
+		return nil, fmt.Errorf("listing buckets: %w", err)
+	}
+
+	var bucketNames []string
+
+	for _, bucket := range buckets {
+		if bucket.Type != nil && *bucket.Type == domain.BucketTypeSystem {
+			continue
+		}
+
+		bucketNames = append(bucketNames, bucket.Name)
+	}
+
+	tasks, err := influxrest.ListTasks(ctx, client, domain.GetTasksParams{OrgID: &orgID})
+
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+
+	var taskNames []string
+
+	for _, task := range tasks {
+		taskNames = append(taskNames, task.Name)
+	}
+
+	var contents []string
+
+	if len(bucketNames) > 0 {
+		contents = append(contents, fmt.Sprintf("%d bucket(s): %s", len(bucketNames), strings.Join(bucketNames, ", ")))
+	}
+
+	if len(taskNames) > 0 {
+		contents = append(contents, fmt.Sprintf("%d task(s): %s", len(taskNames), strings.Join(taskNames, ", ")))
+	}
+
+	return contents, nil
 }
 
 func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
 	var state organizationResourceModel
 
 	// Read Terraform plan state into the model
@@ -108,14 +225,20 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx = withObjectFields(ctx, "influxdbv2_organization", state.Name, state.Id)
+
 	var organization domain.Organization
 	organization.Name = state.Name.ValueString()
 	organization.Description = state.Description.ValueStringPointer()
 
-	newOrganization, err := r.client.OrganizationsAPI().CreateOrganization(context.Background(), &organization)
+	client := r.providerData.clientFor(state.Token)
+
+	newOrganization, err := createAfterDeletion(ctx, r.providerData, organizationDeletionKey(state.Name.ValueString()), func() (*domain.Organization, error) {
+		return client.OrganizationsAPI().CreateOrganization(ctx, &organization)
+	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, organizationErrorAttributes,
 			"Error creating organization",
 			fmt.Sprintf("Error: %s", err),
 		)
@@ -123,17 +246,12 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	state.Id = types.StringPointerValue(newOrganization.Id)
-
-	state.Name = types.StringValue(newOrganization.Name)
-
-	state.Description = types.StringPointerValue(newOrganization.Description)
-
-	state.Status = types.StringPointerValue((*string)(newOrganization.Status))
-
-	state.CreatedAt = types.StringValue(newOrganization.CreatedAt.String())
+	if newOrganization.Id != nil {
+		r.providerData.recordCreation(*newOrganization.Id)
+		ctx = tflog.SetField(ctx, logFieldResourceID, *newOrganization.Id)
+	}
 
-	state.UpdatedAt = types.StringValue(newOrganization.UpdatedAt.String())
+	orgToModel(newOrganization, &state)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -144,6 +262,8 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
 	var state organizationResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -152,10 +272,16 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	organization, err := r.client.OrganizationsAPI().FindOrganizationByID(context.Background(), state.Id.ValueString())
+	ctx = withObjectFields(ctx, "influxdbv2_organization", state.Name, state.Id)
+
+	client := r.providerData.clientFor(state.Token)
+
+	organization, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Organization, error) {
+		return client.OrganizationsAPI().FindOrganizationByID(ctx, state.Id.ValueString())
+	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(ctx, &resp.Diagnostics,
 			"Error reading organization",
 			fmt.Sprintf("Could not read organization %s with ID %s : %s", state.Name, state.Id, err),
 		)
@@ -163,15 +289,7 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	state.Id = types.StringPointerValue(organization.Id)
-
-	state.Description = types.StringPointerValue(organization.Description)
-
-	state.Status = types.StringPointerValue((*string)(organization.Status))
-
-	state.CreatedAt = types.StringValue(organization.CreatedAt.String())
-
-	state.UpdatedAt = types.StringValue(organization.UpdatedAt.String())
+	orgToModel(organization, &state)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -181,6 +299,8 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
 	var plan organizationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -188,6 +308,8 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	ctx = withObjectFields(ctx, "influxdbv2_organization", plan.Name, plan.Id)
+
 	var state organizationResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -195,10 +317,14 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	organization, err := r.client.OrganizationsAPI().FindOrganizationByID(context.Background(), state.Id.ValueString())
+	client := r.providerData.clientFor(plan.Token)
+
+	organization, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Organization, error) {
+		return client.OrganizationsAPI().FindOrganizationByID(ctx, state.Id.ValueString())
+	})
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(ctx, &resp.Diagnostics,
 			"Error reading organization",
 			fmt.Sprintf("Could not read organization %s with ID %s : %s", plan.Name, state.Id.ValueString(), err),
 		)
@@ -218,10 +344,10 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 	organization.Name = plan.Name.ValueString()
 	organization.Description = plan.Description.ValueStringPointer()
 
-	organization, err = r.client.OrganizationsAPI().UpdateOrganization(context.Background(), organization)
+	organization, err = client.OrganizationsAPI().UpdateOrganization(ctx, organization)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, organizationErrorAttributes,
 			"Error updating organization",
 			fmt.Sprintf("Could not update organization %s with ID %s : %s", plan.Name, plan.Id, err),
 		)
@@ -229,15 +355,7 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	plan.Id = types.StringPointerValue(organization.Id)
-
-	plan.Description = types.StringPointerValue(organization.Description)
-
-	plan.Status = types.StringPointerValue((*string)(organization.Status))
-
-	plan.CreatedAt = types.StringValue(organization.CreatedAt.String())
-
-	plan.UpdatedAt = types.StringValue(organization.UpdatedAt.String())
+	orgToModel(organization, &plan)
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -247,6 +365,8 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
 	var state organizationResourceModel
 
 	// Read Terraform prior state data into the model
@@ -256,19 +376,84 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.client.OrganizationsAPI().DeleteOrganizationWithID(context.Background(), state.Id.ValueString())
+	ctx = withObjectFields(ctx, "influxdbv2_organization", state.Name, state.Id)
+
+	if state.RetainOnDelete.ValueBool() {
+		tflog.Warn(ctx, "retain_on_delete is set, leaving organization in place", map[string]interface{}{
+			"id":   state.Id.ValueString(),
+			"name": state.Name.ValueString(),
+		})
+
+		resp.Diagnostics.AddWarning(
+			"Organization retained",
+			fmt.Sprintf("Organization %s with ID %s was removed from the Terraform state but left in place because retain_on_delete is set.", state.Name, state.Id),
+		)
+
+		return
+	}
+
+	client := r.providerData.clientFor(state.Token)
+
+	if !state.CascadeDelete.ValueBool() {
+		contents, err := organizationContents(ctx, client, state.Id.ValueString())
+
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics,
+				"Error deleting organization",
+				fmt.Sprintf("Could not check whether organization %s with ID %s is empty : %s\n\nSet cascade_delete = true to delete it regardless.", state.Name, state.Id, err),
+			)
+
+			return
+		}
+
+		if len(contents) > 0 {
+			resp.Diagnostics.AddError(
+				"Organization is not empty",
+				fmt.Sprintf("Deleting organization %s with ID %s would also destroy:\n\n%s\n\nSet cascade_delete = true to delete it with its contents.",
+					state.Name, state.Id, strings.Join(contents, "\n")),
+			)
+
+			return
+		}
+	}
+
+	err := client.OrganizationsAPI().DeleteOrganizationWithID(ctx, state.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleteing organization",
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting organization",
 			fmt.Sprintf("Could not update organization %s with ID %s : %s", state.Name, state.Id, err),
 		)
 
 		return
 	}
 
+	r.providerData.recordDeletion(organizationDeletionKey(state.Name.ValueString()))
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, deletionTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = waitForDeletion(ctx, deleteTimeout, func(ctx context.Context) error {
+		_, err := client.OrganizationsAPI().FindOrganizationByID(ctx, state.Id.ValueString())
+
+		return err
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting organization",
+			fmt.Sprintf("Organization %s with ID %s was deleted but is still returned by the server : %s", state.Name, state.Id, err),
+		)
+	}
 }
 
 func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_delete"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cascade_delete"), false)...)
 }
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestOrgToModelUpdateMatchesRead(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := "0000000000000001"
+	status := domain.OrganizationStatusActive
+
+	stored := domain.Organization{Id: &id, Name: "team", Status: &status, CreatedAt: &created, UpdatedAt: &created}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var update domain.PatchOrganizationRequest
+
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("unexpected request body: %s", err)
+			}
+
+			// The server trims names, so the state must follow the response.
+			stored.Name = "team-renamed"
+			stored.Description = update.Description
+			updated := created.Add(time.Hour)
+			stored.UpdatedAt = &updated
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(stored)
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+	description := "renamed"
+
+	updated, err := client.OrganizationsAPI().UpdateOrganization(ctx, &domain.Organization{Id: &id, Name: " team-renamed ", Description: &description})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	afterUpdate := organizationResourceModel{Name: types.StringValue(" team-renamed "), Token: types.StringNull(), RetainOnDelete: types.BoolValue(false)}
+	orgToModel(updated, &afterUpdate)
+
+	read, err := client.OrganizationsAPI().FindOrganizationByID(ctx, id)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	afterRead := afterUpdate
+	orgToModel(read, &afterRead)
+
+	if !reflect.DeepEqual(afterUpdate, afterRead) {
+		t.Errorf("expected the state after update to equal the state after read:\n%+v\n%+v", afterUpdate, afterRead)
+	}
+
+	if afterUpdate.Name.ValueString() != "team-renamed" || afterUpdate.Description.ValueString() != "renamed" {
+		t.Errorf("expected the server name and description in state, got %+v", afterUpdate)
+	}
+}
+
+// newOrganizationContentsServer serves buckets with limit/offset pagination
+// and tasks with after pagination.
+func newOrganizationContentsServer(t *testing.T, buckets []domain.Bucket, tasks []domain.Task) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v2/buckets":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			page := buckets[min(offset, len(buckets)):min(offset+limit, len(buckets))]
+
+			_ = json.NewEncoder(w).Encode(domain.Buckets{Buckets: &page})
+		case "/api/v2/tasks":
+			start := 0
+
+			for i, task := range tasks {
+				if task.Id == r.URL.Query().Get("after") {
+					start = i + 1
+				}
+			}
+
+			page := tasks[start:min(start+limit, len(tasks))]
+
+			_ = json.NewEncoder(w).Encode(domain.Tasks{Tasks: &page})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOrganizationContents(t *testing.T) {
+	setListPageSize(t, 2)
+
+	system := domain.BucketTypeSystem
+	user := domain.BucketTypeUser
+
+	server := newOrganizationContentsServer(t,
+		[]domain.Bucket{
+			{Name: "_monitoring", Type: &system},
+			{Name: "_tasks", Type: &system},
+			{Name: "metrics", Type: &user},
+			{Name: "logs", Type: &user},
+			{Name: "traces"},
+		},
+		[]domain.Task{
+			{Id: "0000000000000001", Name: "downsample"},
+			{Id: "0000000000000002", Name: "cleanup"},
+			{Id: "0000000000000003", Name: "report"},
+		},
+	)
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	contents, err := organizationContents(context.Background(), client, "0000000000000001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"3 bucket(s): metrics, logs, traces",
+		"3 task(s): downsample, cleanup, report",
+	}
+
+	if len(contents) != len(expected) || contents[0] != expected[0] || contents[1] != expected[1] {
+		t.Errorf("expected %q, got %q", expected, contents)
+	}
+}
+
+func TestOrganizationContentsEmpty(t *testing.T) {
+	system := domain.BucketTypeSystem
+
+	server := newOrganizationContentsServer(t, []domain.Bucket{{Name: "_monitoring", Type: &system}}, nil)
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	contents, err := organizationContents(context.Background(), client, "0000000000000001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(contents) != 0 {
+		t.Errorf("expected an empty organization, got %q", contents)
+	}
+}
+
+func TestOrganizationDeleteUsesDeleteTimeout(t *testing.T) {
+	setDeletionPolling(t, time.Millisecond, time.Hour)
+
+	id := "0000000000000001"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		// The deleted organization keeps being served.
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(domain.Organization{Id: &id, Name: "team"})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &organizationResource{providerData: newProviderData(server.URL, "token", http.DefaultTransport)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	diags := state.Set(ctx, &organizationResourceModel{
+		Name:           types.StringValue("team"),
+		Id:             types.StringValue(id),
+		Description:    types.StringNull(),
+		Status:         types.StringNull(),
+		CreatedAt:      types.StringNull(),
+		UpdatedAt:      types.StringNull(),
+		Token:          types.StringNull(),
+		RetainOnDelete: types.BoolValue(false),
+		CascadeDelete:  types.BoolValue(true),
+		Timeouts: timeouts.Value{Object: types.ObjectValueMust(
+			map[string]attr.Type{"delete": types.StringType},
+			map[string]attr.Value{"delete": types.StringValue("20ms")},
+		)},
+	})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var resp resource.DeleteResponse
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "deletion did not complete within 20ms") {
+		t.Errorf("expected the wait to be bounded by timeouts.delete, got %v", resp.Diagnostics)
+	}
+}
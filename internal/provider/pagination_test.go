@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// setListPageSize overrides influxrest.PageSize for the duration of a test.
+func setListPageSize(t *testing.T, size int) {
+	previous := influxrest.PageSize
+	influxrest.PageSize = size
+
+	t.Cleanup(func() {
+		influxrest.PageSize = previous
+	})
+}
+
+// newPagedServer serves total items named item-N from path, honouring the
+// limit and offset query parameters, and counts the requests it receives.
+func newPagedServer(t *testing.T, path string, key string, total int, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		*requests++
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		items := []map[string]string{}
+
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]string{
+				"id":   fmt.Sprintf("%016d", i),
+				"name": fmt.Sprintf("item-%d", i),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{key: items})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
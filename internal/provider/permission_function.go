@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &permissionFunction{}
+
+func PermissionFunction() function.Function {
+	return &permissionFunction{}
+}
+
+type permissionFunction struct{}
+
+func (f *permissionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permission"
+}
+
+func (f *permissionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an authorization permission",
+		MarkdownDescription: "Returns a permission object with `action` and a `resource` holding `type`, `id` and `org_id`, as expected by the `permissions` of an authorization.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "action",
+				MarkdownDescription: "Granted action, `read` or `write`",
+			},
+			function.StringParameter{
+				Name:                "resource_type",
+				MarkdownDescription: "Type of the resource, for example `buckets`",
+			},
+			function.StringParameter{
+				Name:                "resource_id",
+				MarkdownDescription: "ID of a single resource, null for every resource of the type",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "org_id",
+				MarkdownDescription: "ID of the organization owning the resources, may be null",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: permissionAttrTypes,
+		},
+	}
+}
+
+func (f *permissionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var action, resourceType string
+	var id, orgID types.String
+
+	resp.Error = req.Arguments.Get(ctx, &action, &resourceType, &id, &orgID)
+
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateOneOf("action", action, permissionActions); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if err := validateOneOf("resource type", resourceType, permissionResourceTypes); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	permission, err := permissionValue(action, resourceType, id, orgID)
+
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, permission)
+}
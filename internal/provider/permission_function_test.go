@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runPermissionFunction(args ...attr.Value) *function.RunResponse {
+	req := function.RunRequest{Arguments: function.NewArgumentsData(args)}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(permissionAttrTypes))}
+
+	PermissionFunction().Run(context.Background(), req, resp)
+
+	return resp
+}
+
+func TestPermissionFunction(t *testing.T) {
+	resp := runPermissionFunction(
+		types.StringValue("read"),
+		types.StringValue("buckets"),
+		types.StringValue("0123456789abcdef"),
+		types.StringValue("fedcba9876543210"),
+	)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	expected, err := permissionValue("read", "buckets", types.StringValue("0123456789abcdef"), types.StringValue("fedcba9876543210"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !resp.Result.Value().Equal(expected) {
+		t.Errorf("unexpected result %s", resp.Result.Value())
+	}
+}
+
+func TestPermissionFunctionNullResource(t *testing.T) {
+	resp := runPermissionFunction(
+		types.StringValue("write"),
+		types.StringValue("orgs"),
+		types.StringNull(),
+		types.StringValue(""),
+	)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	object, ok := resp.Result.Value().(types.Object)
+
+	if !ok {
+		t.Fatalf("unexpected result type %T", resp.Result.Value())
+	}
+
+	resource, ok := object.Attributes()["resource"].(types.Object)
+
+	if !ok {
+		t.Fatalf("unexpected resource type %T", object.Attributes()["resource"])
+	}
+
+	if !resource.Attributes()["id"].IsNull() || !resource.Attributes()["org_id"].IsNull() {
+		t.Errorf("expected null id and org_id, got %s", resource)
+	}
+}
+
+func TestPermissionFunctionValidation(t *testing.T) {
+	tests := []struct {
+		action       string
+		resourceType string
+		argument     int64
+		expectedErr  string
+	}{
+		{action: "delete", resourceType: "buckets", argument: 0, expectedErr: `action "delete" is not valid, expected one of: read, write`},
+		{action: "read", resourceType: "bucket", argument: 1, expectedErr: `resource type "bucket" is not valid, expected one of: annotations,`},
+	}
+
+	for _, test := range tests {
+		resp := runPermissionFunction(
+			types.StringValue(test.action),
+			types.StringValue(test.resourceType),
+			types.StringNull(),
+			types.StringNull(),
+		)
+
+		if resp.Error == nil {
+			t.Fatalf("expected an error for %s %s", test.action, test.resourceType)
+		}
+
+		if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != test.argument {
+			t.Errorf("expected error on argument %d, got %v", test.argument, resp.Error.FunctionArgument)
+		}
+
+		if !strings.HasPrefix(resp.Error.Text, test.expectedErr) {
+			t.Errorf("unexpected error %q", resp.Error.Text)
+		}
+	}
+}
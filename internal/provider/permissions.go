@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// permissionActions lists the actions a permission may grant.
+var permissionActions = []string{
+	string(domain.PermissionActionRead),
+	string(domain.PermissionActionWrite),
+}
+
+// permissionResourceTypes lists the resource types a permission may apply to.
+var permissionResourceTypes = []string{
+	string(domain.ResourceTypeAnnotations),
+	string(domain.ResourceTypeAuthorizations),
+	string(domain.ResourceTypeBuckets),
+	string(domain.ResourceTypeChecks),
+	string(domain.ResourceTypeDashboards),
+	string(domain.ResourceTypeDbrp),
+	string(domain.ResourceTypeDocuments),
+	string(domain.ResourceTypeInstance),
+	string(domain.ResourceTypeLabels),
+	string(domain.ResourceTypeNotebooks),
+	string(domain.ResourceTypeNotificationEndpoints),
+	string(domain.ResourceTypeNotificationRules),
+	string(domain.ResourceTypeOrgs),
+	string(domain.ResourceTypeRemotes),
+	string(domain.ResourceTypeReplications),
+	string(domain.ResourceTypeScrapers),
+	string(domain.ResourceTypeSecrets),
+	string(domain.ResourceTypeSources),
+	string(domain.ResourceTypeTasks),
+	string(domain.ResourceTypeTelegrafs),
+	string(domain.ResourceTypeUsers),
+	string(domain.ResourceTypeVariables),
+	string(domain.ResourceTypeViews),
+}
+
+var permissionResourceAttrTypes = map[string]attr.Type{
+	"type":   types.StringType,
+	"id":     types.StringType,
+	"org_id": types.StringType,
+}
+
+var permissionAttrTypes = map[string]attr.Type{
+	"action":   types.StringType,
+	"resource": types.ObjectType{AttrTypes: permissionResourceAttrTypes},
+}
+
+// validateOneOf checks value against the allowed values, listing them in
+// the error.
+func validateOneOf(kind string, value string, allowed []string) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s %q is not valid, expected one of: %s", kind, value, strings.Join(allowed, ", "))
+}
+
+// permissionValue builds the permission object, with id and orgID left null
+// when null or empty.
+func permissionValue(action string, resourceType string, id types.String, orgID types.String) (types.Object, error) {
+	resource, diags := types.ObjectValue(permissionResourceAttrTypes, map[string]attr.Value{
+		"type":   types.StringValue(resourceType),
+		"id":     stringValueOrNull(id.ValueStringPointer()),
+		"org_id": stringValueOrNull(orgID.ValueStringPointer()),
+	})
+
+	if diags.HasError() {
+		return types.ObjectNull(permissionAttrTypes), fmt.Errorf("building permission resource: %v", diags)
+	}
+
+	permission, diags := types.ObjectValue(permissionAttrTypes, map[string]attr.Value{
+		"action":   types.StringValue(action),
+		"resource": resource,
+	})
+
+	if diags.HasError() {
+		return types.ObjectNull(permissionAttrTypes), fmt.Errorf("building permission: %v", diags)
+	}
+
+	return permission, nil
+}
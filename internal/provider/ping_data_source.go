@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &pingDataSource{}
+	_ datasource.DataSourceWithConfigure = &pingDataSource{}
+)
+
+func PingDataSource() datasource.DataSource {
+	return &pingDataSource{}
+}
+
+type pingDataSource struct {
+	client influxdb2.Client
+	host   string
+}
+
+// pingDataSourceModel describes the data source data model.
+type pingDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Reachable types.Bool   `tfsdk:"reachable"`
+	LatencyMs types.Int64  `tfsdk:"latency_ms"`
+	Version   types.String `tfsdk:"version"`
+	Build     types.String `tfsdk:"build"`
+}
+
+// pingResult is the outcome of a successful /ping call.
+type pingResult struct {
+	latency time.Duration
+	version string
+	build   string
+}
+
+func (d *pingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+func (d *pingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reachability probe of the InfluxDB server using the `/ping` endpoint. An unreachable server is reported through `reachable` instead of failing the read.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Host that was pinged",
+				Computed:            true,
+			},
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the server answered the ping",
+				Computed:            true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Round trip time of the ping in milliseconds, null when the server is not reachable",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Server version from the `X-Influxdb-Version` header",
+				Computed:            true,
+			},
+			"build": schema.StringAttribute{
+				MarkdownDescription: "Server build from the `X-Influxdb-Build` header",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *pingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+	d.host = data.host
+}
+
+// pingServer calls /ping, which lives next to /api/v2 rather than under it.
+// Both the 204 answer of older servers and a 200 with a body are accepted.
+func pingServer(ctx context.Context, client influxdb2.Client) (pingResult, error) {
+	api := client.APIClient()
+	endpoint := strings.TrimSuffix(api.APIEndpoint, "api/v2/") + "ping"
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return pingResult{}, err
+	}
+
+	start := time.Now()
+	rsp, err := api.Client.Do(httpReq)
+
+	if err != nil {
+		return pingResult{}, err
+	}
+
+	defer func() { _ = rsp.Body.Close() }()
+
+	payload, err := io.ReadAll(rsp.Body)
+	latency := time.Since(start)
+
+	if err != nil {
+		return pingResult{}, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return pingResult{}, influxrest.DecodeError(rsp, payload)
+	}
+
+	return pingResult{
+		latency: latency,
+		version: rsp.Header.Get("X-Influxdb-Version"),
+		build:   rsp.Header.Get("X-Influxdb-Build"),
+	}, nil
+}
+
+func (d *pingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state pingDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(d.host)
+
+	result, err := pingServer(ctx, d.client)
+
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Ping of %s failed: %s", d.host, err))
+
+		state.Reachable = types.BoolValue(false)
+		state.LatencyMs = types.Int64Null()
+		state.Version = types.StringNull()
+		state.Build = types.StringNull()
+	} else {
+		state.Reachable = types.BoolValue(true)
+		state.LatencyMs = types.Int64Value(result.latency.Milliseconds())
+		state.Version = stringValueOrNull(&result.version)
+		state.Build = stringValueOrNull(&result.build)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
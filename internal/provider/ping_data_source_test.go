@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPingServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("X-Influxdb-Version", "v2.7.5")
+		w.Header().Set("X-Influxdb-Build", "OSS")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	result, err := pingServer(context.Background(), client)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.version != "v2.7.5" || result.build != "OSS" {
+		t.Errorf("unexpected ping result %+v", result)
+	}
+}
+
+func TestPingServerUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	if _, err := pingServer(context.Background(), client); err == nil {
+		t.Error("expected an error for a closed server")
+	}
+}
@@ -5,19 +5,30 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 )
 
 // Ensure InfluxdbV2Provider satisfies various provider interfaces.
-var _ provider.Provider = &InfluxdbV2Provider{}
+var (
+	_ provider.Provider              = &InfluxdbV2Provider{}
+	_ provider.ProviderWithFunctions = &InfluxdbV2Provider{}
+)
 
 // InfluxdbV2Provider defines the provider implementation.
 type InfluxdbV2Provider struct {
@@ -31,6 +42,23 @@ type InfluxdbV2Provider struct {
 type InfluxdbV2ProviderModel struct {
 	Host   types.String `tfsdk:"host"`
 	ApiKey types.String `tfsdk:"api_key"`
+
+	TokenCommand types.String `tfsdk:"token_command"`
+
+	MaxRequestsPerSecond  types.Int64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	TLSMinVersion   types.String `tfsdk:"tls_min_version"`
+	TLSCipherSuites types.List   `tfsdk:"tls_cipher_suites"`
+
+	AuthScheme types.String `tfsdk:"auth_scheme"`
+
+	SkipPermissionCheck types.Bool `tfsdk:"skip_permission_check"`
+	AllowHTTP           types.Bool `tfsdk:"allow_http"`
+	ValidateFluxOnPlan  types.Bool `tfsdk:"validate_flux_on_plan"`
+
+	DefaultRetentionRules types.List `tfsdk:"default_retention_rules"`
+	DefaultLabels         types.List `tfsdk:"default_labels"`
 }
 
 func (p *InfluxdbV2Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -42,7 +70,7 @@ func (p *InfluxdbV2Provider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Influxdb hostname",
+				MarkdownDescription: "Influxdb hostname, or `unix:///path/to/socket` to connect through a unix domain socket",
 				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
@@ -50,6 +78,67 @@ func (p *InfluxdbV2Provider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token_command": schema.StringAttribute{
+				MarkdownDescription: "Command printing the API key on its standard output, used instead of `api_key`, for example `vault read -field=token secret/influxdb`. " +
+					"Arguments are split like a shell does, without variable expansion or any other shell syntax.",
+				Optional: true,
+			},
+			"auth_scheme": schema.StringAttribute{
+				MarkdownDescription: "Scheme of the Authorization header, `token` (`Token <api_key>`) or `bearer` (`Bearer <api_key>`, required by InfluxDB Cloud Dedicated). " +
+					"Detected by probing the server when unset.",
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second shared by all resources and data sources. Unset or `0` means unlimited.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time. Unset or `0` means unlimited.",
+				Optional:            true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version negotiated with the server, `1.2` or `1.3`. Defaults to `1.2`.",
+				Optional:            true,
+			},
+			"tls_cipher_suites": schema.ListAttribute{
+				MarkdownDescription: "TLS 1.2 cipher suites the client may use, by Go name such as `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`. TLS 1.3 suites are not configurable.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"allow_http": schema.BoolAttribute{
+				MarkdownDescription: "Allow sending the token over plain HTTP to hosts other than localhost. " +
+					"Without it, `http://` hosts on private networks get a warning and other `http://` hosts are rejected.",
+				Optional: true,
+			},
+			"default_retention_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Retention rules applied to every `influxdbv2_bucket` that does not set `retention_rules`.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"every_seconds": schema.Int64Attribute{
+							Required: true,
+						},
+						"retention_type": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
+			"default_labels": schema.ListAttribute{
+				MarkdownDescription: "Names or ids of existing labels attached to every bucket, task, check and notification endpoint the provider manages. " +
+					"The labels attached to a resource show in its `effective_labels`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"validate_flux_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Analyze the Flux scripts of tasks and checks with the server at plan time, reporting errors before apply. " +
+					"Resources can override it with `validate_on_plan`.",
+				Optional: true,
+			},
+			"skip_permission_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip inspecting the permissions of `api_key` when the provider is configured, for tokens that cannot read their own authorization.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -71,28 +160,275 @@ func (p *InfluxdbV2Provider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.TokenCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown InfluxdbV2 token command",
+			"The provider cannot create the InfluxdbV2 API client as there is an unknown configuration value for token_command. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	limits := []struct {
+		attribute string
+		value     types.Int64
+	}{
+		{"max_requests_per_second", config.MaxRequestsPerSecond},
+		{"max_concurrent_requests", config.MaxConcurrentRequests},
+	}
+
+	for _, limit := range limits {
+		if limit.value.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(limit.attribute),
+				"Invalid request limit",
+				fmt.Sprintf("%s must be zero or positive, got %d.", limit.attribute, limit.value.ValueInt64()),
+			)
+		}
+	}
+
+	if !config.AuthScheme.IsNull() {
+		if err := validateOneOf("auth_scheme", config.AuthScheme.ValueString(), authSchemes); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("auth_scheme"), "Invalid authorization scheme", err.Error()+".")
+		}
+	}
+
+	baseTransport := tlsTransport(ctx, config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	influxHost := config.Host.ValueString()
 	influxCredential := config.ApiKey.ValueString()
 
-	influxClient := influxdb2.NewClient(influxHost, influxCredential)
+	if !config.TokenCommand.IsNull() {
+		if !config.ApiKey.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_command"),
+				"Conflicting InfluxdbV2 API credentials",
+				"Set either api_key or token_command, not both.",
+			)
+
+			return
+		}
+
+		token, elapsed, err := runTokenCommand(ctx, config.TokenCommand.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_command"),
+				"Error running token_command",
+				fmt.Sprintf("Could not get the API key from token_command: %s", err),
+			)
+
+			return
+		}
+
+		if elapsed > tokenCommandSlowThreshold {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("token_command"),
+				"Slow token_command",
+				fmt.Sprintf("token_command took %s and runs every time the provider is configured.", elapsed.Round(time.Millisecond)),
+			)
+		}
+
+		influxCredential = token
+	}
+
+	if !config.AllowHTTP.ValueBool() {
+		switch classifyPlainHTTP(influxHost) {
+		case plainHTTPPrivate:
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("host"),
+				"Insecure InfluxdbV2 API Host",
+				fmt.Sprintf("The token is sent unencrypted to %s. Use https, or set allow_http = true to silence this warning.", influxHost),
+			)
+		case plainHTTPPublic:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Insecure InfluxdbV2 API Host",
+				fmt.Sprintf("The token would be sent unencrypted to %s. Use https, or set allow_http = true if this is intended.", influxHost),
+			)
+
+			return
+		}
+	}
 
-	resp.DataSourceData = influxClient
-	resp.ResourceData = influxClient
+	if socket, ok := unixSocketPath(influxHost); ok {
+		if !config.TLSMinVersion.IsNull() || !config.TLSCipherSuites.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Invalid InfluxdbV2 API Host",
+				"TLS options do not apply to unix socket hosts, remove tls_min_version and tls_cipher_suites.",
+			)
+
+			return
+		}
+
+		baseTransport = newUnixSocketTransport(socket)
+		influxHost = unixSocketHost
+	}
+
+	transport := newRateLimitTransport(baseTransport, config.MaxRequestsPerSecond.ValueInt64(), config.MaxConcurrentRequests.ValueInt64())
+
+	transport, err := withAuthScheme(ctx, influxHost, influxCredential, config.AuthScheme.ValueString(), transport)
+
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Authorization scheme detection failed, using %s: %s", authSchemeToken, err))
+	}
+
+	data := newProviderData(influxHost, influxCredential, transport)
+
+	if !config.DefaultRetentionRules.IsNull() && !config.DefaultRetentionRules.IsUnknown() {
+		data.defaultRetentionRules = config.DefaultRetentionRules
+	}
+
+	resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &data.defaultLabels, false)...)
+
+	data.validateFluxOnPlan = config.ValidateFluxOnPlan.ValueBool()
+
+	if influxHost != "" {
+		err := detectUnsupportedBackend(withRequestID(ctx), data.client)
+
+		var unsupported *unsupportedBackendError
+
+		if errors.As(err, &unsupported) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Unsupported InfluxDB server",
+				fmt.Sprintf("The server at %s was detected as %s. This provider targets the InfluxDB 2.x management API "+
+					"(/api/v2) implemented by InfluxDB 2.x and InfluxDB Cloud; point host at one of those.", influxHost, unsupported.backend),
+			)
+
+			return
+		}
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping backend detection: %s", err))
+		}
+	}
+
+	if influxHost != "" && influxCredential != "" && !config.SkipPermissionCheck.ValueBool() {
+		missing, err := missingTokenPermissions(withRequestID(ctx), data.client, influxCredential)
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping the token permission check: %s", err))
+		}
+
+		data.missingPermissions = missing
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
+
+// tlsTransport validates the TLS settings of config and returns the transport
+// to send requests with.
+func tlsTransport(ctx context.Context, config InfluxdbV2ProviderModel, diags *diag.Diagnostics) http.RoundTripper {
+	if config.TLSMinVersion.IsNull() && config.TLSCipherSuites.IsNull() {
+		return http.DefaultTransport
+	}
+
+	minVersion := config.TLSMinVersion.ValueString()
+
+	if _, ok := tlsVersions[minVersion]; !ok && !config.TLSMinVersion.IsNull() {
+		diags.AddAttributeError(
+			path.Root("tls_min_version"),
+			"Invalid TLS version",
+			fmt.Sprintf("tls_min_version must be one of %s, got %q.", strings.Join(tlsVersionNames(), ", "), minVersion),
+		)
+	}
+
+	var names []string
+
+	diags.Append(config.TLSCipherSuites.ElementsAs(ctx, &names, false)...)
+
+	var cipherSuites []uint16
+
+	for _, name := range names {
+		id, ok := cipherSuiteID(name)
+
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("tls_cipher_suites"),
+				"Invalid TLS cipher suite",
+				fmt.Sprintf("%q is not a secure TLS 1.2 cipher suite supported by the provider.", name),
+			)
+
+			continue
+		}
+
+		cipherSuites = append(cipherSuites, id)
+	}
+
+	if len(names) > 0 && minVersion == "1.3" {
+		diags.AddAttributeWarning(
+			path.Root("tls_cipher_suites"),
+			"TLS cipher suites ignored",
+			"tls_cipher_suites only applies to TLS 1.2 and tls_min_version is 1.3.",
+		)
+	}
+
+	return newTLSTransport(minVersion, cipherSuites)
 }
 
 func (p *InfluxdbV2Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		BucketResource,
 		OrganizationResource,
+		CheckCustomResource,
+		DownsamplingTaskResource,
+		NotificationEndpointTelegramResource,
+		AuthorizationResource,
+		OrganizationMemberResource,
+		OrganizationOwnerResource,
+		OrganizationMembersResource,
+		BucketMemberResource,
+		TaskResource,
 	}
 }
 
 func (p *InfluxdbV2Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		OrganizationDataSource,
+		TemplateExportDataSource,
+		UsageDataSource,
+		LimitsDataSource,
+		AnnotationsDataSource,
+		PingDataSource,
+		NotificationEndpointDataSource,
+		CheckDataSource,
+		NotificationRuleDataSource,
+		TelegrafPluginsDataSource,
+		MeasurementsDataSource,
+		SchemaKeysDataSource,
+		BucketCardinalityDataSource,
+		SetupStatusDataSource,
 	}
 }
 
+func (p *InfluxdbV2Provider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		LineProtocolFunction,
+		PermissionFunction,
+	}
+}
+
+// newInfluxClient creates an InfluxDB client sending requests through
+// transport, logging every call and recording the request id of every
+// response for error diagnostics.
+func newInfluxClient(host string, token string, transport http.RoundTripper) influxdb2.Client {
+	options := influxdb2.DefaultOptions()
+
+	options.SetHTTPClient(&http.Client{
+		Timeout:   time.Second * time.Duration(options.HTTPRequestTimeout()),
+		Transport: &requestIDTransport{next: &apiLogTransport{next: transport}},
+	})
+
+	return influxdb2.NewClientWithOptions(host, token, options)
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &InfluxdbV2Provider{
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// providerData is handed to resources and data sources by Configure.
+type providerData struct {
+	// client is authenticated with the token configured on the provider.
+	client influxdb2.Client
+
+	host string
+	// transport is shared by every client so limits apply provider wide.
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	tokenClients map[string]influxdb2.Client
+	// defaultRetentionRules applies to buckets without retention_rules, null
+	// when the provider does not set default_retention_rules.
+	defaultRetentionRules types.List
+
+	// defaultLabels holds the label names or ids attached to every resource
+	// that supports labels.
+	defaultLabels []string
+
+	// validateFluxOnPlan enables the server side analysis of Flux scripts
+	// at plan time for resources that do not set validate_on_plan.
+	validateFluxOnPlan bool
+
+	// missingPermissions holds the permission types of managedPermissions
+	// the provider token cannot write, and permissionWarned whether they
+	// were reported.
+	missingPermissions map[domain.ResourceType]bool
+	permissionWarned   bool
+
+	// deletions holds the keys of the objects deleted during this run.
+	deletions map[string]bool
+
+	// creations holds the creation time of the objects created during this
+	// run, by id.
+	creations map[string]time.Time
+}
+
+func newProviderData(host string, token string, transport http.RoundTripper) *providerData {
+	return &providerData{
+		client:       newInfluxClient(host, token, transport),
+		host:         host,
+		transport:    transport,
+		tokenClients: map[string]influxdb2.Client{},
+		deletions:    map[string]bool{},
+		creations:    map[string]time.Time{},
+
+		defaultRetentionRules: types.ListNull(types.ObjectType{AttrTypes: bucketRetentionRulesAttrTypes}),
+	}
+}
+
+// clientFor returns a client authenticated with the token override of a
+// resource, or the provider client when no override is set. Override clients
+// are created on first use and cached per token.
+func (d *providerData) clientFor(token types.String) influxdb2.Client {
+	if token.IsNull() || token.IsUnknown() || token.ValueString() == "" {
+		return d.client
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	client, ok := d.tokenClients[token.ValueString()]
+
+	if !ok {
+		client = newInfluxClient(d.host, token.ValueString(), d.transport)
+		d.tokenClients[token.ValueString()] = client
+	}
+
+	return client
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderDataClientFor(t *testing.T) {
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"0000000000000001","name":"bucket"}`))
+	}))
+	defer server.Close()
+
+	data := newProviderData(server.URL, "provider-token", http.DefaultTransport)
+
+	if data.clientFor(types.StringNull()) != data.client || data.clientFor(types.StringValue("")) != data.client {
+		t.Error("expected the provider client when no token override is set")
+	}
+
+	override := data.clientFor(types.StringValue("org-token"))
+
+	if override == data.client {
+		t.Error("expected a dedicated client for the token override")
+	}
+
+	if data.clientFor(types.StringValue("org-token")) != override {
+		t.Error("expected the token override client to be cached")
+	}
+
+	if _, err := override.BucketsAPI().FindBucketByID(context.Background(), "0000000000000001"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if authorization != "Token org-token" {
+		t.Errorf("expected the override token to be sent, got %q", authorization)
+	}
+}
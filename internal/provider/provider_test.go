@@ -4,22 +4,123 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
+//
+// The provider runs inside the test binary, so a single acceptance test can be
+// debugged end to end with `make testacc-debug TEST=TestAccBucketResource`
+// and a debugger attached to localhost:2345.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"influxdbv2": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccPreCheck fails acceptance tests missing the environment describing
+// the InfluxDB server they run against: INFLUXDB_HOST, INFLUXDB_TOKEN, an
+// operator token, and INFLUXDB_ORG, the name of an existing organization.
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	for _, name := range []string{"INFLUXDB_HOST", "INFLUXDB_TOKEN", "INFLUXDB_ORG"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests", name)
+		}
+	}
+}
+
+// testAccProviderConfig returns the provider block of acceptance test
+// configurations, followed by an influxdbv2_organization data source named
+// test for the INFLUXDB_ORG organization.
+func testAccProviderConfig() string {
+	return fmt.Sprintf(`
+provider "influxdbv2" {
+  host       = %q
+  api_key    = %q
+  allow_http = true
+}
+
+data "influxdbv2_organization" "test" {
+  name = %q
+}
+`, os.Getenv("INFLUXDB_HOST"), os.Getenv("INFLUXDB_TOKEN"), os.Getenv("INFLUXDB_ORG"))
+}
+
+// testAccClient returns a client of the acceptance test server, for setting
+// up resources outside of Terraform, and the id of the INFLUXDB_ORG
+// organization.
+func testAccClient(t *testing.T) (influxdb2.Client, string) {
+	t.Helper()
+
+	client := newInfluxClient(os.Getenv("INFLUXDB_HOST"), os.Getenv("INFLUXDB_TOKEN"), http.DefaultTransport)
+	t.Cleanup(client.Close)
+
+	org, err := client.OrganizationsAPI().FindOrganizationByName(context.Background(), os.Getenv("INFLUXDB_ORG"))
+
+	if err != nil {
+		t.Fatalf("could not find the acceptance test organization: %s", err)
+	}
+
+	return client, *org.Id
+}
+
+// planResourceChange plans the change of a resource of typeName through the
+// provider server, like Terraform does, so schema plan modifiers and
+// replacements are exercised. proposed is the proposed new state Terraform
+// computes from prior and config.
+func planResourceChange(t *testing.T, typeName string, prior tftypes.Value, config tftypes.Value, proposed tftypes.Value) (tftypes.Value, []*tftypes.AttributePath) {
+	t.Helper()
+
+	server, err := testAccProtoV6ProviderFactories["influxdbv2"]()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	values := make([]*tfprotov6.DynamicValue, 0, 3)
+
+	for _, value := range []tftypes.Value{prior, config, proposed} {
+		dynamic, err := tfprotov6.NewDynamicValue(value.Type(), value)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		values = append(values, &dynamic)
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       values[0],
+		Config:           values[1],
+		ProposedNewState: values[2],
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(prior.Type())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return planned, resp.RequiresReplace
 }
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenBucket allows rate requests per second on average, with bursts of up
+// to one second worth of requests.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate int64) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done. It sleeps on a timer
+// in the calling goroutine, so a cancelled request leaves nothing behind.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+
+			return nil
+		}
+
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rateLimitTransport throttles the requests sent through it. It is shared by
+// every client of a provider instance, so the limits apply to all resources
+// and data sources together.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *tokenBucket
+	// slots holds one element per request in flight, nil when unlimited.
+	slots chan struct{}
+}
+
+// newRateLimitTransport wraps next with the configured limits. Zero means
+// unlimited, and next is returned as is when both limits are zero.
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond int64, concurrentRequests int64) http.RoundTripper {
+	if requestsPerSecond <= 0 && concurrentRequests <= 0 {
+		return next
+	}
+
+	transport := &rateLimitTransport{next: next}
+
+	if requestsPerSecond > 0 {
+		transport.limiter = newTokenBucket(requestsPerSecond)
+	}
+
+	if concurrentRequests > 0 {
+		transport.slots = make(chan struct{}, concurrentRequests)
+	}
+
+	return transport
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.wait(ctx); err != nil {
+			t.release()
+
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if resp == nil || t.slots == nil {
+		t.release()
+
+		return resp, err
+	}
+
+	// The request stays in flight until its body has been consumed.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, err
+}
+
+func (t *rateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releasingBody frees the concurrency slot of its request when closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
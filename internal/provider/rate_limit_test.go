@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimitTransportUnlimited(t *testing.T) {
+	if newRateLimitTransport(http.DefaultTransport, 0, 0) != http.DefaultTransport {
+		t.Error("expected the wrapped transport when no limit is set")
+	}
+}
+
+func TestTokenBucketWaitsForTokens(t *testing.T) {
+	bucket := newTokenBucket(10)
+	ctx := context.Background()
+	start := time.Now()
+
+	for i := 0; i < 12; i++ {
+		if err := bucket.wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first 10 tokens are available immediately, the next 2 take 100ms each.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the bucket to throttle, took %s", elapsed)
+	}
+}
+
+func TestTokenBucketHonoursCancellation(t *testing.T) {
+	bucket := newTokenBucket(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.wait(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := bucket.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context deadline, got %v", err)
+	}
+}
+
+func TestRateLimitTransportLimitsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+
+		for {
+			previous := atomic.LoadInt32(&maxInFlight)
+
+			if current <= previous || atomic.CompareAndSwapInt32(&maxInFlight, previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Get(server.URL)
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+
+				return
+			}
+
+			_ = resp.Body.Close()
+		}()
+	}
+
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
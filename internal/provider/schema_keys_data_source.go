@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &schemaKeysDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemaKeysDataSource{}
+)
+
+const (
+	// schemaKeysDefaultStart is the scan window used when start is not set.
+	schemaKeysDefaultStart = "-30d"
+
+	// schemaKeysDefaultTagValuesLimit caps tag_values when
+	// tag_values_limit is not set.
+	schemaKeysDefaultTagValuesLimit = 100
+)
+
+// schemaSystemKeys are returned by schema.tagKeys along with the tag keys.
+var schemaSystemKeys = map[string]bool{
+	"_start":       true,
+	"_stop":        true,
+	"_measurement": true,
+	"_field":       true,
+}
+
+func SchemaKeysDataSource() datasource.DataSource {
+	return &schemaKeysDataSource{}
+}
+
+type schemaKeysDataSource struct {
+	client influxdb2.Client
+}
+
+// schemaKeysDataSourceModel describes the data source data model.
+type schemaKeysDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Org            types.String `tfsdk:"org"`
+	Bucket         types.String `tfsdk:"bucket"`
+	Measurement    types.String `tfsdk:"measurement"`
+	Start          types.String `tfsdk:"start"`
+	TagValuesKey   types.String `tfsdk:"tag_values_key"`
+	TagValuesLimit types.Int64  `tfsdk:"tag_values_limit"`
+	TagKeys        types.Set    `tfsdk:"tag_keys"`
+	FieldKeys      types.Set    `tfsdk:"field_keys"`
+	TagValues      types.Set    `tfsdk:"tag_values"`
+}
+
+func (d *schemaKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_keys"
+}
+
+func (d *schemaKeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tag keys, field keys and optionally the values of one tag of a bucket or measurement, discovered with the Flux schema package.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Bucket, and measurement when set, the keys were read from",
+				Computed:            true,
+			},
+			"org": schema.StringAttribute{
+				MarkdownDescription: "Organization name or id",
+				Required:            true,
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Bucket name",
+				Required:            true,
+			},
+			"measurement": schema.StringAttribute{
+				MarkdownDescription: "Only return the keys of this measurement",
+				Optional:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Earliest time scanned, a duration such as `-90d` or an RFC3339 timestamp. Defaults to `-30d`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"tag_values_key": schema.StringAttribute{
+				MarkdownDescription: "Tag key whose values are returned in `tag_values`",
+				Optional:            true,
+			},
+			"tag_values_limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of values returned in `tag_values`. Defaults to %d.", schemaKeysDefaultTagValuesLimit),
+				Optional:            true,
+				Computed:            true,
+			},
+			"tag_keys": schema.SetAttribute{
+				MarkdownDescription: "Tag keys",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"field_keys": schema.SetAttribute{
+				MarkdownDescription: "Field keys",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"tag_values": schema.SetAttribute{
+				MarkdownDescription: "Values of the `tag_values_key` tag, null when `tag_values_key` is not set",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *schemaKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// schemaKeysQueries holds the queries run by the data source.
+type schemaKeysQueries struct {
+	tagKeys   string
+	fieldKeys string
+	// tagValues is empty when no tag values are requested.
+	tagValues string
+}
+
+// newSchemaKeysQueries builds the schema queries of bucket, restricted to
+// measurement when it is not empty.
+func newSchemaKeysQueries(bucket string, measurement string, start string, tagValuesKey string, tagValuesLimit int64) (schemaKeysQueries, error) {
+	var queries schemaKeysQueries
+
+	startLiteral, err := fluxTime(start)
+
+	if err != nil {
+		return queries, err
+	}
+
+	predicate := "(r) => true"
+
+	if measurement != "" {
+		predicate = "(r) => r._measurement == " + fluxString(measurement)
+	}
+
+	arguments := []string{"bucket: " + fluxString(bucket), "predicate: " + predicate, "start: " + startLiteral}
+
+	queries.tagKeys = schemaQuery("tagKeys", arguments...)
+	queries.fieldKeys = schemaQuery("fieldKeys", arguments...)
+
+	if tagValuesKey != "" {
+		queries.tagValues = schemaQuery("tagValues", append(arguments, "tag: "+fluxString(tagValuesKey))...) +
+			fmt.Sprintf("\n  |> limit(n: %d)", tagValuesLimit)
+	}
+
+	return queries, nil
+}
+
+func (d *schemaKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state schemaKeysDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Start.IsNull() {
+		state.Start = types.StringValue(schemaKeysDefaultStart)
+	}
+
+	if state.TagValuesLimit.IsNull() {
+		state.TagValuesLimit = types.Int64Value(schemaKeysDefaultTagValuesLimit)
+	}
+
+	if state.TagValuesLimit.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag_values_limit"),
+			"Invalid tag values limit",
+			fmt.Sprintf("tag_values_limit must be positive, got %d.", state.TagValuesLimit.ValueInt64()),
+		)
+
+		return
+	}
+
+	queries, err := newSchemaKeysQueries(
+		state.Bucket.ValueString(),
+		state.Measurement.ValueString(),
+		state.Start.ValueString(),
+		state.TagValuesKey.ValueString(),
+		state.TagValuesLimit.ValueInt64(),
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("start"), "Invalid start", err.Error())
+
+		return
+	}
+
+	lists := []struct {
+		name   string
+		query  string
+		target *types.Set
+	}{
+		{"tag keys", queries.tagKeys, &state.TagKeys},
+		{"field keys", queries.fieldKeys, &state.FieldKeys},
+		{"tag values", queries.tagValues, &state.TagValues},
+	}
+
+	for _, list := range lists {
+		if list.query == "" {
+			*list.target = types.SetNull(types.StringType)
+
+			continue
+		}
+
+		values, err := queryStrings(ctx, d.client, state.Org.ValueString(), list.query)
+
+		if err != nil {
+			addAPIError(ctx, &resp.Diagnostics,
+				"Error reading schema keys",
+				fmt.Sprintf("Could not list the %s of bucket %s : %s", list.name, state.Bucket, err),
+			)
+
+			return
+		}
+
+		if list.target == &state.TagKeys {
+			values = withoutSystemKeys(values)
+		}
+
+		var diags diag.Diagnostics
+
+		*list.target, diags = types.SetValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	state.Id = state.Bucket
+
+	if !state.Measurement.IsNull() {
+		state.Id = types.StringValue(state.Bucket.ValueString() + "/" + state.Measurement.ValueString())
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func withoutSystemKeys(keys []string) []string {
+	filtered := []string{}
+
+	for _, key := range keys {
+		if !schemaSystemKeys[key] {
+			filtered = append(filtered, key)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestNewSchemaKeysQueries(t *testing.T) {
+	queries, err := newSchemaKeysQueries("metrics", "cpu", "-7d", "host", 20)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	arguments := `bucket: "metrics", predicate: (r) => r._measurement == "cpu", start: -7d`
+
+	expected := schemaKeysQueries{
+		tagKeys:   "import \"influxdata/influxdb/schema\"\n\nschema.tagKeys(" + arguments + ")",
+		fieldKeys: "import \"influxdata/influxdb/schema\"\n\nschema.fieldKeys(" + arguments + ")",
+		tagValues: "import \"influxdata/influxdb/schema\"\n\nschema.tagValues(" + arguments + ", tag: \"host\")\n  |> limit(n: 20)",
+	}
+
+	if queries != expected {
+		t.Errorf("expected %+v, got %+v", expected, queries)
+	}
+
+	queries, err = newSchemaKeysQueries("metrics", "", schemaKeysDefaultStart, "", schemaKeysDefaultTagValuesLimit)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if queries.tagKeys != "import \"influxdata/influxdb/schema\"\n\nschema.tagKeys(bucket: \"metrics\", predicate: (r) => true, start: -30d)" || queries.tagValues != "" {
+		t.Errorf("expected bucket wide queries without tag values, got %+v", queries)
+	}
+
+	if _, err := newSchemaKeysQueries("metrics", "", "1 week", "", 1); err == nil {
+		t.Error("expected an invalid start to be rejected")
+	}
+}
+
+func TestWithoutSystemKeys(t *testing.T) {
+	keys := withoutSystemKeys([]string{"_start", "_stop", "_measurement", "_field", "host", "region"})
+
+	if len(keys) != 2 || keys[0] != "host" || keys[1] != "region" {
+		t.Errorf("expected only the tag keys, got %v", keys)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &setupStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &setupStatusDataSource{}
+)
+
+func SetupStatusDataSource() datasource.DataSource {
+	return &setupStatusDataSource{}
+}
+
+type setupStatusDataSource struct {
+	client influxdb2.Client
+	host   string
+}
+
+// setupStatusDataSourceModel describes the data source data model.
+type setupStatusDataSourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Allowed types.Bool   `tfsdk:"allowed"`
+}
+
+func (d *setupStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setup_status"
+}
+
+func (d *setupStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Onboarding status of the InfluxDB server. The `/api/v2/setup` endpoint needs no authorization, " +
+			"so this data source also works when the provider has no `api_key`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Host that was queried",
+				Computed:            true,
+			},
+			"allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the initial setup is allowed, true when the server has not been onboarded yet",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *setupStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+	d.host = data.host
+}
+
+// setupAllowed calls GET /api/v2/setup. The request is sent without an
+// Authorization header, so it succeeds whatever the provider credential.
+func setupAllowed(ctx context.Context, client influxdb2.Client) (bool, error) {
+	api := client.APIClient()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, api.APIEndpoint+"setup", nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	rsp, err := api.Client.Do(httpReq)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer func() { _ = rsp.Body.Close() }()
+
+	payload, err := io.ReadAll(rsp.Body)
+
+	if err != nil {
+		return false, err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return false, influxrest.DecodeError(rsp, payload)
+	}
+
+	var status domain.IsOnboarding
+
+	if err := json.Unmarshal(payload, &status); err != nil {
+		return false, err
+	}
+
+	if status.Allowed == nil {
+		return false, fmt.Errorf("the setup status does not include allowed")
+	}
+
+	return *status.Allowed, nil
+}
+
+func (d *setupStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state setupStatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allowed, err := setupAllowed(ctx, d.client)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading setup status",
+			fmt.Sprintf("Could not read the setup status of %s : %s", d.host, err),
+		)
+
+		return
+	}
+
+	state.Id = types.StringValue(d.host)
+	state.Allowed = types.BoolValue(allowed)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetupAllowed(t *testing.T) {
+	for _, test := range []struct {
+		body     string
+		expected bool
+	}{
+		{body: `{"allowed": true}`, expected: true},
+		{body: `{"allowed": false}`, expected: false},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/setup" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+
+			if header := r.Header.Get("Authorization"); header != "" {
+				t.Errorf("unexpected Authorization header %q", header)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(test.body))
+		}))
+
+		client := newInfluxClient(server.URL, "", http.DefaultTransport)
+
+		allowed, err := setupAllowed(context.Background(), client)
+
+		client.Close()
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if allowed != test.expected {
+			t.Errorf("expected allowed %t for %s, got %t", test.expected, test.body, allowed)
+		}
+	}
+}
+
+func TestSetupAllowedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code": "internal error", "message": "unavailable"}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "", http.DefaultTransport)
+	defer client.Close()
+
+	if _, err := setupAllowed(context.Background(), client); err == nil {
+		t.Error("expected an error for a failed request")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// taskOptionPattern matches the start of the option task statement.
+var taskOptionPattern = regexp.MustCompile(`^option\s+task\s*=\s*\{`)
+
+// sameTaskFlux reports whether the task scripts a and b only differ in
+// whitespace or in their option task statement. Besides reformatting it, the
+// server rewrites and moves the option task statement when the schedule is
+// set, and changes of the name and schedule show on the attributes holding
+// them.
+func sameTaskFlux(a string, b string) bool {
+	return normalizeFlux(withoutTaskOption(a)) == normalizeFlux(withoutTaskOption(b))
+}
+
+// taskFlux returns the script to keep in the state, given the prior one and
+// the one stored by the server: prior when they are the same, so the
+// configured formatting is kept, stored otherwise.
+func taskFlux(prior types.String, stored string) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && sameTaskFlux(prior.ValueString(), stored) {
+		return prior
+	}
+
+	return types.StringValue(stored)
+}
+
+// withoutTaskOption removes the option task statement from script, wherever
+// it is placed.
+func withoutTaskOption(script string) string {
+	if start, end := taskOptionBounds(script); start >= 0 {
+		return script[:start] + script[end:]
+	}
+
+	return script
+}
+
+// taskOptionFieldPattern matches a field of the option task record and its
+// value, a string literal or a bare value such as a duration.
+var taskOptionFieldPattern = regexp.MustCompile(`([A-Za-z_]\w*)\s*:\s*("(?:[^"\\]|\\.)*"|[^,}\s]+)`)
+
+// taskOption returns the value of the field name of the option task
+// statement of script, with string literals unquoted, or "" when the script
+// does not set it.
+func taskOption(script string, name string) string {
+	start, end := taskOptionBounds(script)
+
+	if start < 0 {
+		return ""
+	}
+
+	for _, match := range taskOptionFieldPattern.FindAllStringSubmatch(script[start:end], -1) {
+		if match[1] != name {
+			continue
+		}
+
+		if value, err := strconv.Unquote(match[2]); err == nil {
+			return value
+		}
+
+		return match[2]
+	}
+
+	return ""
+}
+
+// taskOptionBounds returns the start and end of the option task statement
+// of script, or -1 and -1 when there is none. Braces inside string literals
+// and comments of the statement are skipped.
+func taskOptionBounds(script string) (int, int) {
+	inString, inComment, escaped, lineStart := false, false, false, true
+
+	for i, r := range script {
+		switch {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if r == '\\' {
+				escaped = true
+			} else if r == '"' {
+				inString = false
+			}
+		case inComment:
+			if r == '\n' {
+				inComment, lineStart = false, true
+			}
+		case r == '"':
+			inString, lineStart = true, false
+		case strings.HasPrefix(script[i:], "//"):
+			inComment = true
+		case r == '\n':
+			lineStart = true
+		case r == ' ' || r == '\t' || r == '\r':
+		case lineStart && taskOptionPattern.MatchString(script[i:]):
+			if end := recordEnd(script, i+len(taskOptionPattern.FindString(script[i:]))); end >= 0 {
+				return i, end
+			}
+
+			return -1, -1
+		default:
+			lineStart = false
+		}
+	}
+
+	return -1, -1
+}
+
+// recordEnd returns the index following the brace closing the record whose
+// fields start at start in script, or -1 when the record is not closed.
+func recordEnd(script string, start int) int {
+	depth, inString, inComment, escaped := 1, false, false, false
+
+	for i := start; i < len(script); i++ {
+		switch c := script[i]; {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case inComment:
+			inComment = c != '\n'
+		case c == '"':
+			inString = true
+		case strings.HasPrefix(script[i:], "//"):
+			inComment = true
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestSameTaskFlux(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "whitespace",
+			prior:    "option task = {name: \"t\", every: 1h}\n\nfrom(bucket: \"a\")\n  |> range(start: -1h)",
+			new:      "option task = {name: \"t\", every: 1h}\nfrom(bucket: \"a\")\n\t|> range(start: -1h)\n",
+			expected: true,
+		},
+		{
+			name:     "rewritten option",
+			prior:    "option task = {name: \"t\", every: 1h}\n\nfrom(bucket: \"a\")",
+			new:      "option task = {\n    name: \"t\",\n    cron: \"0 * * * *\",\n}\n\nfrom(bucket: \"a\")\n",
+			expected: true,
+		},
+		{
+			name:     "moved option",
+			prior:    "import \"strings\"\n\noption task = {name: \"t\", every: 1h}\n\nfrom(bucket: \"a\")",
+			new:      "option task = {name: \"t\", every: 1h}\n\nimport \"strings\"\n\nfrom(bucket: \"a\")",
+			expected: true,
+		},
+		{
+			name:     "braces in option strings",
+			prior:    "option task = {name: \"t}\", every: 1h} // {\nfrom(bucket: \"a\")",
+			new:      "option task = {name: \"{t\", every: 2h} // {\nfrom(bucket: \"a\")",
+			expected: true,
+		},
+		{
+			name:     "option in a comment",
+			prior:    "// option task = {name: \"t\"}\nfrom(bucket: \"a\")",
+			new:      "// option task = {name: \"u\"}\nfrom(bucket: \"a\")",
+			expected: false,
+		},
+		{
+			name:     "other option",
+			prior:    "option v = {timeRangeStart: -1h}\nfrom(bucket: \"a\")",
+			new:      "option v = {timeRangeStart: -2h}\nfrom(bucket: \"a\")",
+			expected: false,
+		},
+		{
+			name:     "different script",
+			prior:    "option task = {name: \"t\", every: 1h}\nfrom(bucket: \"a\")",
+			new:      "option task = {name: \"t\", every: 1h}\nfrom(bucket: \"b\")",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if equal := sameTaskFlux(test.prior, test.new); equal != test.expected {
+				t.Errorf("expected sameness %t, got %q and %q", test.expected, withoutTaskOption(test.prior), withoutTaskOption(test.new))
+			}
+		})
+	}
+}
+
+func TestTaskOption(t *testing.T) {
+	script := "import \"strings\"\n\noption task = {\n    name: \"every: 2h, t}\",\n    cron: \"0 * * * *\",\n    offset: 5m,\n}\n\nfrom(bucket: \"a\")"
+
+	for name, expected := range map[string]string{"name": "every: 2h, t}", "cron": "0 * * * *", "offset": "5m", "every": ""} {
+		if got := taskOption(script, name); got != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+
+	if got := taskOption("from(bucket: \"a\")", "every"); got != "" {
+		t.Errorf("expected no value without option task statement, got %q", got)
+	}
+}
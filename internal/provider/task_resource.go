@@ -0,0 +1,708 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &taskResource{}
+	_ resource.ResourceWithImportState    = &taskResource{}
+	_ resource.ResourceWithModifyPlan     = &taskResource{}
+	_ resource.ResourceWithValidateConfig = &taskResource{}
+)
+
+func TaskResource() resource.Resource {
+	return &taskResource{}
+}
+
+// taskResource defines the resource implementation.
+type taskResource struct {
+	providerData *providerData
+}
+
+// taskResourceModel describes the resource data model.
+type taskResourceModel struct {
+	Id              types.String      `tfsdk:"id"`
+	OrgID           types.String      `tfsdk:"org_id"`
+	Flux            types.String      `tfsdk:"flux"`
+	ExactMatch      types.Bool        `tfsdk:"exact_match"`
+	Status          types.String      `tfsdk:"status"`
+	Name            types.String      `tfsdk:"name"`
+	Every           fluxDurationValue `tfsdk:"every"`
+	Cron            types.String      `tfsdk:"cron"`
+	Offset          fluxDurationValue `tfsdk:"offset"`
+	CreatedAt       types.String      `tfsdk:"created_at"`
+	UpdatedAt       types.String      `tfsdk:"updated_at"`
+	LastRunStatus   types.String      `tfsdk:"last_run_status"`
+	LastRunError    types.String      `tfsdk:"last_run_error"`
+	WaitForFirstRun types.Bool        `tfsdk:"wait_for_first_run"`
+	FirstRunTimeout types.String      `tfsdk:"first_run_timeout"`
+	EffectiveLabels types.Set         `tfsdk:"effective_labels"`
+	ValidateOnPlan  types.Bool        `tfsdk:"validate_on_plan"`
+}
+
+func (r *taskResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task"
+}
+
+func (r *taskResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Flux task. The name and schedule are defined by the `option task` statement of the script.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Task id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"flux": schema.StringAttribute{
+				MarkdownDescription: "Flux script of the task, including the `option task` statement. " +
+					"Differences in whitespace or in the `option task` statement of the script stored by the server are ignored " +
+					"unless `exact_match` is set, changes of the name and schedule show on `name`, `every`, `cron` and `offset`.",
+				Required: true,
+			},
+			"exact_match": schema.BoolAttribute{
+				MarkdownDescription: "Report any difference between `flux` and the script stored by the server as drift, byte for byte. " +
+					"The server rewrites the `option task` statement when `every`, `cron` or `offset` is set, which then always shows as drift.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Task status, `active` or `inactive`. Changing it pauses or resumes the task without changing its script or schedule.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Task name, parsed from the script",
+				Computed:            true,
+			},
+			"every": schema.StringAttribute{
+				MarkdownDescription: "Interval the task runs at, a Flux duration such as `1h`. " +
+					"Overrides the schedule of the script, conflicts with `cron`. Defaults to the schedule of the script.",
+				Optional:   true,
+				Computed:   true,
+				CustomType: fluxDurationType{},
+			},
+			"cron": schema.StringAttribute{
+				MarkdownDescription: "Cron expression with 5 fields the task runs at. " +
+					"Overrides the schedule of the script, conflicts with `every`. Defaults to the schedule of the script.",
+				Optional: true,
+				Computed: true,
+			},
+			"offset": schema.StringAttribute{
+				MarkdownDescription: "Delay of the runs after their scheduled time, a Flux duration such as `5m`. " +
+					"Overrides the offset of the script. Defaults to the offset of the script.",
+				Optional:   true,
+				Computed:   true,
+				CustomType: fluxDurationType{},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update time",
+				Computed:            true,
+			},
+			"last_run_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the last completed run, `success`, `failed` or `canceled`. Null until the task has run.",
+				Computed:            true,
+			},
+			"last_run_error": schema.StringAttribute{
+				MarkdownDescription: "Error of the last completed run, null when it succeeded.",
+				Computed:            true,
+			},
+			"wait_for_first_run": schema.BoolAttribute{
+				MarkdownDescription: "Run the task once right after creating it and fail the apply when the run fails. " +
+					"The task is kept and tainted, so the next apply recreates it.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"first_run_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the first run, a duration such as `5m`. Defaults to `" + defaultFirstRunTimeout + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultFirstRunTimeout),
+			},
+			"effective_labels": effectiveLabelsAttribute("Labels attached to the task, including the provider `default_labels`"),
+			"validate_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Analyze the Flux script with the server at plan time. Defaults to the provider `validate_flux_on_plan`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *taskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *taskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config taskResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateFirstRunTimeout(config.FirstRunTimeout, &resp.Diagnostics)
+	validateTaskSchedule(config.Every.StringValue, config.Cron, &resp.Diagnostics)
+	validateFluxDuration("offset", config.Offset.StringValue, &resp.Diagnostics)
+
+	if !config.Status.IsUnknown() && !config.Status.IsNull() {
+		if err := validateOneOf("status", config.Status.ValueString(), taskStatuses); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("status"), "Invalid task status", err.Error()+".")
+		}
+	}
+}
+
+// validateTaskSchedule checks that at most one of every and cron is set and
+// that the one set is valid. Unknown values are only checked once known.
+func validateTaskSchedule(every types.String, cron types.String, diags *diag.Diagnostics) {
+	if !every.IsNull() && !every.IsUnknown() && !cron.IsNull() && !cron.IsUnknown() {
+		diags.AddAttributeError(path.Root("cron"), "Conflicting task schedules",
+			"Set only one of every and cron.")
+
+		return
+	}
+
+	validateFluxDuration("every", every, diags)
+
+	if !cron.IsUnknown() && !cron.IsNull() {
+		if err := validateCron(cron.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("cron"), "Invalid cron expression", err.Error()+".")
+		}
+	}
+}
+
+// cronFields lists the name and the range of values of the fields of a cron
+// expression, in order.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// validateCron checks that expression is a cron expression with 5 fields,
+// each a comma separated list of *, values or ranges with an optional step.
+func validateCron(expression string) error {
+	fields := strings.Fields(expression)
+
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected a cron expression with %d fields such as \"0 * * * *\", got %q", len(cronFields), expression)
+	}
+
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			values, step, hasStep := strings.Cut(item, "/")
+
+			if hasStep {
+				if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+					return fmt.Errorf("invalid step %q in the %s field of %q", step, cronFields[i].name, expression)
+				}
+			}
+
+			if values == "*" {
+				continue
+			}
+
+			from, to, isRange := strings.Cut(values, "-")
+
+			bounds := []string{from}
+
+			if isRange {
+				bounds = append(bounds, to)
+			}
+
+			for _, bound := range bounds {
+				if n, err := strconv.Atoi(bound); err != nil || n < cronFields[i].min || n > cronFields[i].max {
+					return fmt.Errorf("the %s field of %q must be *, or values between %d and %d, got %q",
+						cronFields[i].name, expression, cronFields[i].min, cronFields[i].max, item)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ModifyPlan keeps the name and schedule in the plan when the script does
+// not change, so pausing or resuming a task only shows the status change,
+// plans a schedule override removed from the configuration as the schedule of
+// the script, and analyzes the script with the server when validate_on_plan is enabled.
+// It also plans effective_labels as unknown when a provider default_labels
+// entry is missing from the task, and warns when the provider token cannot
+// manage tasks.
+func (r *taskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.warnMissingPermission("influxdbv2_task", &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan taskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state taskResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.Flux.Equal(state.Flux) {
+			var config taskResourceModel
+
+			resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			script := state.Flux.ValueString()
+
+			plan.Name = state.Name
+			plan.Every = fluxDurationValue{StringValue: plannedScheduleValue(config.Every.StringValue, state.Every.StringValue, config.Cron, taskOption(script, "every"), sameFluxDuration)}
+			plan.Cron = plannedScheduleValue(config.Cron, state.Cron, config.Every.StringValue, taskOption(script, "cron"), sameCron)
+			plan.Offset = fluxDurationValue{StringValue: plannedScheduleValue(config.Offset.StringValue, state.Offset.StringValue, types.StringNull(), taskOption(script, "offset"), sameFluxDuration)}
+
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+	}
+
+	validateFluxOnPlan(ctx, r.providerData, plan.ValidateOnPlan, path.Root("flux"), plan.Flux, &resp.Diagnostics)
+	planEffectiveLabels(ctx, r.providerData, req, resp)
+}
+
+// plannedScheduleValue plans the every, cron or offset attribute of a task
+// whose script does not change, given its configured and prior values, the
+// configured value of the attribute it conflicts with and its value in the
+// option task statement of the script. Without a configured value the server
+// keeps the prior value when it is the one of the script, clears it when the
+// conflicting attribute is set, and otherwise reverts to the script once the
+// override is removed, so the value is unknown until then.
+func plannedScheduleValue(configured types.String, prior types.String, conflicting types.String, script string, same func(a string, b string) bool) types.String {
+	switch {
+	case !configured.IsNull():
+		return configured
+	case conflicting.IsUnknown():
+		return types.StringUnknown()
+	case !conflicting.IsNull():
+		return types.StringNull()
+	case prior.IsNull() && script == "":
+		return prior
+	case !prior.IsNull() && !prior.IsUnknown() && same(prior.ValueString(), script):
+		return prior
+	}
+
+	return types.StringUnknown()
+}
+
+// sameFluxDuration reports whether a and b are the same Flux duration,
+// spelled alike or not.
+func sameFluxDuration(a string, b string) bool {
+	equal, _ := fluxDurationValue{StringValue: types.StringValue(a)}.StringSemanticEquals(context.Background(), fluxDurationValue{StringValue: types.StringValue(b)})
+
+	return equal
+}
+
+// sameCron reports whether a and b are the same cron expression, ignoring
+// whitespace.
+func sameCron(a string, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+// taskStatuses lists the statuses of a task.
+var taskStatuses = []string{string(domain.TaskStatusTypeActive), string(domain.TaskStatusTypeInactive)}
+
+// taskErrorAttributes lists the attributes API validation errors of task
+// requests can be attached to.
+var taskErrorAttributes = map[string]bool{
+	"org_id": true,
+}
+
+// taskToModel maps the server representation of a task to the resource
+// model. The prior script is kept when the one returned by the server is the
+// same.
+func taskToModel(ctx context.Context, task *domain.Task, model *taskResourceModel) diag.Diagnostics {
+	model.Id = types.StringValue(task.Id)
+	model.OrgID = types.StringValue(task.OrgID)
+	model.Flux = taskFlux(model.Flux, task.Flux)
+	model.Status = stringValueOrNull((*string)(task.Status))
+	model.Name = types.StringValue(task.Name)
+	model.Every = fluxDurationValue{StringValue: stringValueOrNull(task.Every)}
+	model.Cron = stringValueOrNull(task.Cron)
+	model.Offset = fluxDurationValue{StringValue: stringValueOrNull(task.Offset)}
+	model.CreatedAt = timeValue(task.CreatedAt)
+	model.UpdatedAt = timeValue(task.UpdatedAt)
+	model.LastRunStatus = stringValueOrNull((*string)(task.LastRunStatus))
+	model.LastRunError = stringValueOrNull(task.LastRunError)
+
+	effectiveLabels, diags := flattenLabels(ctx, task.Labels)
+	model.EffectiveLabels = effectiveLabels
+
+	return diags
+}
+
+// createTask creates the task described by model. The status is left to
+// the server default when not configured.
+func createTask(ctx context.Context, client influxdb2.Client, model taskResourceModel) (*domain.Task, error) {
+	orgID := model.OrgID.ValueString()
+
+	body := domain.PostTasksJSONRequestBody{
+		OrgID: &orgID,
+		Flux:  model.Flux.ValueString(),
+	}
+
+	if status := stringValueOrNull(model.Status.ValueStringPointer()); !status.IsNull() {
+		body.Status = (*domain.TaskStatusType)(status.ValueStringPointer())
+	}
+
+	return client.APIClient().PostTasks(ctx, &domain.PostTasksAllParams{Body: body})
+}
+
+// taskScheduleSet reports whether the schedule of the task described by
+// model overrides the one of its script.
+func taskScheduleSet(model taskResourceModel) bool {
+	for _, value := range []types.String{model.Every.StringValue, model.Cron, model.Offset.StringValue} {
+		if !stringValueOrNull(value.ValueStringPointer()).IsNull() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changedValue returns the planned value when it is set and differs from the
+// one in state, nil otherwise.
+func changedValue(plan types.String, state types.String) *string {
+	if plan.IsUnknown() || plan.IsNull() || plan.Equal(state) {
+		return nil
+	}
+
+	return plan.ValueStringPointer()
+}
+
+// knownValue returns value when it is set, nil otherwise.
+func knownValue(value types.String) *string {
+	if value.IsUnknown() || value.IsNull() {
+		return nil
+	}
+
+	return value.ValueStringPointer()
+}
+
+// updateTask patches the script, schedule and status of the task with the
+// ones of plan that differ from state, so pausing a task leaves its script
+// and schedule alone. The server rewrites the option task statement of the
+// script with the schedule. The task is only read when nothing changed.
+func updateTask(ctx context.Context, client influxdb2.Client, plan taskResourceModel, state taskResourceModel) (*domain.Task, error) {
+	body := domain.PatchTasksIDJSONRequestBody{
+		Flux:   changedValue(plan.Flux, state.Flux),
+		Every:  changedValue(plan.Every.StringValue, state.Every.StringValue),
+		Cron:   changedValue(plan.Cron, state.Cron),
+		Offset: changedValue(plan.Offset.StringValue, state.Offset.StringValue),
+		Status: (*domain.TaskStatusType)(changedValue(plan.Status, state.Status)),
+	}
+
+	// Sending the script again makes the server take the schedule of its
+	// option task statement, reverting removed overrides, and the ones still
+	// configured are sent along so they are kept.
+	for _, schedule := range [][2]types.String{
+		{plan.Every.StringValue, state.Every.StringValue},
+		{plan.Cron, state.Cron},
+		{plan.Offset.StringValue, state.Offset.StringValue},
+	} {
+		if body.Flux == nil && schedule[0].IsUnknown() && !schedule[1].IsNull() {
+			body.Flux = plan.Flux.ValueStringPointer()
+		}
+	}
+
+	if body.Flux != nil {
+		body.Every = knownValue(plan.Every.StringValue)
+		body.Cron = knownValue(plan.Cron)
+		body.Offset = knownValue(plan.Offset.StringValue)
+	}
+
+	if body == (domain.PatchTasksIDJSONRequestBody{}) {
+		return client.TasksAPI().GetTaskByID(ctx, plan.Id.ValueString())
+	}
+
+	return client.APIClient().PatchTasksID(ctx, &domain.PatchTasksIDAllParams{TaskID: plan.Id.ValueString(), Body: body})
+}
+
+func (r *taskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withRequestID(ctx)
+
+	var state taskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_task", state.Name, state.Id)
+
+	task, err := createTask(ctx, r.providerData.client, state)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, taskErrorAttributes,
+			"Error creating task",
+			fmt.Sprintf("Could not create task : %s", err),
+		)
+
+		return
+	}
+
+	r.providerData.recordCreation(task.Id)
+	ctx = tflog.SetField(ctx, logFieldResourceID, task.Id)
+
+	// Tasks are created with the schedule of their script, a configured
+	// schedule is applied afterwards.
+	if taskScheduleSet(state) {
+		state.Id = types.StringValue(task.Id)
+
+		scheduled, err := updateTask(ctx, r.providerData.client, state, taskResourceModel{Flux: state.Flux, Status: state.Status})
+
+		if err != nil {
+			resp.Diagnostics.Append(taskToModel(ctx, task, &state)...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+			addAPIError(ctx, &resp.Diagnostics,
+				"Error scheduling task",
+				fmt.Sprintf("Task %s with ID %s was created but its schedule could not be set: %s", state.Name, task.Id, err),
+			)
+
+			return
+		}
+
+		task = scheduled
+	}
+
+	labelled, err := applyTaskDefaultLabels(ctx, r.providerData, task)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling task",
+			fmt.Sprintf("Task %s with ID %s was created but : %s", task.Name, task.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(taskToModel(ctx, labelled, &state)...)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || !state.WaitForFirstRun.ValueBool() {
+		return
+	}
+
+	timeout, _ := time.ParseDuration(state.FirstRunTimeout.ValueString())
+
+	if err := waitForFirstRun(ctx, r.providerData.client, task.Id, timeout); err != nil {
+		resp.Diagnostics.AddError(
+			"First run of task failed",
+			fmt.Sprintf("Task %s with ID %s was created but its first run failed: %s", state.Name, task.Id, err),
+		)
+
+		return
+	}
+
+	// Read the task again so last_run_status reflects the first run.
+	ran, err := r.providerData.client.TasksAPI().GetTaskByID(ctx, task.Id)
+
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Could not read the task after its first run: %s", err))
+
+		return
+	}
+
+	resp.Diagnostics.Append(taskToModel(ctx, ran, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *taskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state taskResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_task", state.Name, state.Id)
+
+	task, err := findAfterCreate(ctx, r.providerData, state.Id.ValueString(), func(ctx context.Context) (*domain.Task, error) {
+		return r.providerData.client.TasksAPI().GetTaskByID(ctx, state.Id.ValueString())
+	})
+
+	if isNotFound(err) {
+		tflog.Warn(ctx, "task not found, removing it from the state")
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading task",
+			fmt.Sprintf("Could not read task %s with ID %s : %s", state.Name, state.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(taskToModel(ctx, task, &state)...)
+
+	if state.ExactMatch.ValueBool() {
+		state.Flux = types.StringValue(task.Flux)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *taskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withRequestID(ctx)
+
+	var plan, state taskResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_task", plan.Name, plan.Id)
+
+	task, err := updateTask(ctx, r.providerData.client, plan, state)
+
+	if err != nil {
+		addAttributeAPIError(ctx, &resp.Diagnostics, err, taskErrorAttributes,
+			"Error updating task",
+			fmt.Sprintf("Could not update task %s with ID %s : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	task, err = applyTaskDefaultLabels(ctx, r.providerData, task)
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error labelling task",
+			fmt.Sprintf("Task %s with ID %s was updated but : %s", plan.Name, plan.Id, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(taskToModel(ctx, task, &plan)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *taskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withRequestID(ctx)
+
+	var state taskResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withObjectFields(ctx, "influxdbv2_task", state.Name, state.Id)
+
+	err := r.providerData.client.TasksAPI().DeleteTaskWithID(ctx, state.Id.ValueString())
+
+	if err != nil && !isNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error deleting task",
+			fmt.Sprintf("Could not delete task %s with ID %s : %s", state.Name, state.Id, err),
+		)
+	}
+}
+
+func (r *taskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_first_run"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exact_match"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("first_run_timeout"), defaultFirstRunTimeout)...)
+}
@@ -0,0 +1,717 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// newTaskServer stores a single task, created by POST and patched by PATCH,
+// and records the methods of the requests it received and the body of the
+// last PATCH. The schedule of a new task is fixed, the server would parse it
+// from the script. Patching the script takes its schedule, and patching the
+// schedule rewrites the first line of the script, like the server rewrites
+// its option task statement.
+func newTaskServer(t *testing.T, methods *[]string, patch *domain.TaskUpdateRequest) *httptest.Server {
+	var task *domain.Task
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		*methods = append(*methods, r.Method)
+
+		switch {
+		case r.Method == http.MethodPost:
+			var body domain.TaskCreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			status := domain.TaskStatusTypeActive
+
+			if body.Status != nil {
+				status = *body.Status
+			}
+
+			task = &domain.Task{Id: "0000000000000001", OrgID: *body.OrgID, Name: "downsample", Every: &[]string{"1h"}[0], Flux: body.Flux, Status: &status}
+
+			w.WriteHeader(http.StatusCreated)
+		case task == nil:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "not found", "message": "task not found"}`))
+
+			return
+		case r.Method == http.MethodPatch:
+			var body domain.TaskUpdateRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*patch = body
+
+			if body.Flux != nil {
+				task.Flux = *body.Flux
+				every, cron := taskOption(task.Flux, "every"), taskOption(task.Flux, "cron")
+				task.Every, task.Cron = stringValueOrNull(&every).ValueStringPointer(), stringValueOrNull(&cron).ValueStringPointer()
+			}
+
+			if body.Every != nil || body.Cron != nil {
+				task.Every, task.Cron = body.Every, body.Cron
+				schedule := "every: " + stringValueOrNull(body.Every).ValueString()
+
+				if body.Cron != nil {
+					schedule = "cron: " + fluxString(*body.Cron)
+				}
+
+				task.Flux = "option task = {name: \"downsample\", " + schedule + "}" + task.Flux[strings.Index(task.Flux, "\n"):]
+			}
+
+			if body.Status != nil {
+				task.Status = (*domain.TaskStatusType)(body.Status)
+			}
+		case r.Method == http.MethodDelete:
+			task = nil
+
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(task)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func taskPlanFor(t *testing.T, model taskResourceModel) tfsdk.Plan {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&taskResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return plan
+}
+
+// taskConfigFor returns the configuration of model, in which the schedule
+// attributes left unknown are not set.
+func taskConfigFor(t *testing.T, model taskResourceModel) tfsdk.Config {
+	for _, value := range []*types.String{&model.Every.StringValue, &model.Cron, &model.Offset.StringValue} {
+		if value.IsUnknown() {
+			*value = types.StringNull()
+		}
+	}
+
+	plan := taskPlanFor(t, model)
+
+	return tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+}
+
+func taskModel() taskResourceModel {
+	return taskResourceModel{
+		Id:              types.StringUnknown(),
+		OrgID:           types.StringValue("0000000000000002"),
+		Flux:            types.StringValue("option task = {name: \"downsample\", every: 1h}\n\nfrom(bucket: \"raw\") |> range(start: -task.every)\n"),
+		Status:          types.StringUnknown(),
+		Name:            types.StringUnknown(),
+		Every:           fluxDurationValue{StringValue: types.StringUnknown()},
+		Cron:            types.StringUnknown(),
+		Offset:          fluxDurationValue{StringValue: types.StringUnknown()},
+		CreatedAt:       types.StringUnknown(),
+		UpdatedAt:       types.StringUnknown(),
+		ExactMatch:      types.BoolValue(false),
+		WaitForFirstRun: types.BoolValue(false),
+		FirstRunTimeout: types.StringValue(defaultFirstRunTimeout),
+		EffectiveLabels: types.SetUnknown(types.ObjectType{AttrTypes: effectiveLabelAttrTypes}),
+	}
+}
+
+func TestTaskLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	var methods []string
+
+	data := newProviderData(newTaskServer(t, &methods, &domain.TaskUpdateRequest{}).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &taskResource{providerData: data}
+
+	plan := taskPlanFor(t, taskModel())
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created taskResourceModel
+	createResp.State.Get(ctx, &created)
+
+	if created.Id.ValueString() != "0000000000000001" || created.Name.ValueString() != "downsample" || created.Every.ValueString() != "1h" ||
+		created.Status.ValueString() != "active" || !created.Cron.IsNull() {
+		t.Errorf("unexpected state after create: %+v", created)
+	}
+
+	// Changing the script updates the task in place.
+	updated := created
+	updated.Flux = types.StringValue("option task = {name: \"downsample\", every: 1h}\n\nfrom(bucket: \"raw\") |> range(start: -2h)\n")
+	plan = taskPlanFor(t, updated)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	var flux types.String
+	updateResp.State.GetAttribute(ctx, path.Root("flux"), &flux)
+
+	if !flux.Equal(updated.Flux) || methods[len(methods)-1] != http.MethodPatch {
+		t.Errorf("expected the script to be patched, got %s after %v", flux, methods)
+	}
+
+	// Deleting a task that is already gone succeeds, and reading it removes
+	// it from the state.
+	defer func(window time.Duration) { readAfterCreateWindow = window }(readAfterCreateWindow)
+	readAfterCreateWindow = 0
+
+	for i := 0; i < 2; i++ {
+		deleteResp := resource.DeleteResponse{State: updateResp.State}
+		r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+
+		if deleteResp.Diagnostics.HasError() {
+			t.Errorf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+		}
+	}
+
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected a deleted task to be removed from the state, got %v %s", readResp.Diagnostics, readResp.State.Raw)
+	}
+}
+
+func TestTaskCreateWithSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	var methods []string
+
+	data := newProviderData(newTaskServer(t, &methods, &domain.TaskUpdateRequest{}).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	model := taskModel()
+	model.Cron = types.StringValue("0 * * * *")
+	plan := taskPlanFor(t, model)
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	(&taskResource{providerData: data}).Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created taskResourceModel
+	createResp.State.Get(ctx, &created)
+
+	if strings.Join(methods, " ") != "POST PATCH" || created.Cron.ValueString() != "0 * * * *" || !created.Every.IsNull() {
+		t.Errorf("expected the schedule to be patched after the creation, got %v and %+v", methods, created)
+	}
+
+	if !created.Flux.Equal(model.Flux) {
+		t.Errorf("expected the configured script to be kept over the rewritten one, got %s", created.Flux)
+	}
+}
+
+func TestTaskValidateConfigSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		every, cron types.String
+		errorPath   path.Path
+	}{
+		{types.StringNull(), types.StringNull(), path.Empty()},
+		{types.StringValue("1h30m"), types.StringNull(), path.Empty()},
+		{types.StringNull(), types.StringValue("*/15 0-6,22 * 1 1-5"), path.Empty()},
+		{types.StringValue("1h"), types.StringValue("0 * * * *"), path.Root("cron")},
+		{types.StringUnknown(), types.StringValue("0 * * * *"), path.Empty()},
+		{types.StringValue("1h"), types.StringUnknown(), path.Empty()},
+		{types.StringValue("90 minutes"), types.StringNull(), path.Root("every")},
+		{types.StringNull(), types.StringValue("0 * * *"), path.Root("cron")},
+		{types.StringNull(), types.StringValue("60 * * * *"), path.Root("cron")},
+		{types.StringNull(), types.StringValue("0 * * * */0"), path.Root("cron")},
+	} {
+		model := taskModel()
+		model.Every, model.Cron = fluxDurationValue{StringValue: test.every}, test.cron
+		plan := taskPlanFor(t, model)
+		resp := resource.ValidateConfigResponse{}
+
+		(&taskResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+		if test.errorPath.Equal(path.Empty()) {
+			if resp.Diagnostics.HasError() {
+				t.Errorf("%s %s: unexpected diagnostics: %v", test.every, test.cron, resp.Diagnostics)
+			}
+
+			continue
+		}
+
+		errors := resp.Diagnostics.Errors()
+
+		if len(errors) != 1 {
+			t.Errorf("%s %s: expected an error, got %v", test.every, test.cron, resp.Diagnostics)
+
+			continue
+		}
+
+		if withPath, ok := errors[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(test.errorPath) {
+			t.Errorf("%s %s: expected an error on %s, got %v", test.every, test.cron, test.errorPath, errors[0])
+		}
+	}
+}
+
+func TestTaskPause(t *testing.T) {
+	ctx := context.Background()
+
+	var methods []string
+
+	var patch domain.TaskUpdateRequest
+
+	data := newProviderData(newTaskServer(t, &methods, &patch).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &taskResource{providerData: data}
+
+	plan := taskPlanFor(t, taskModel())
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created taskResourceModel
+	createResp.State.Get(ctx, &created)
+
+	// Only the status changes, the computed schedule is planned unknown by
+	// the framework.
+	paused := created
+	paused.Status = types.StringValue("inactive")
+	paused.Every, paused.Offset = fluxDurationValue{StringValue: types.StringUnknown()}, fluxDurationValue{StringValue: types.StringUnknown()}
+	paused.Cron, paused.UpdatedAt = types.StringUnknown(), types.StringUnknown()
+	plan = taskPlanFor(t, paused)
+	modifyResp := resource.ModifyPlanResponse{Plan: plan}
+
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: taskConfigFor(t, paused), Plan: plan, State: createResp.State}, &modifyResp)
+
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", modifyResp.Diagnostics)
+	}
+
+	var planned taskResourceModel
+	modifyResp.Plan.Get(ctx, &planned)
+
+	if planned.Every.ValueString() != "1h" || !planned.Cron.IsNull() {
+		t.Errorf("expected the schedule to be kept in the plan, got %s %s", planned.Every, planned.Cron)
+	}
+
+	updateResp := resource.UpdateResponse{State: createResp.State}
+
+	r.Update(ctx, resource.UpdateRequest{Plan: modifyResp.Plan, State: createResp.State}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if patch.Status == nil || *patch.Status != domain.TaskStatusTypeInactive || patch.Flux != nil || patch.Every != nil || patch.Cron != nil || patch.Offset != nil {
+		t.Errorf("expected only the status to be patched, got %+v", patch)
+	}
+
+	var status types.String
+	updateResp.State.GetAttribute(ctx, path.Root("status"), &status)
+
+	if status.ValueString() != "inactive" {
+		t.Errorf("expected the task to be paused, got %s", status)
+	}
+
+	// A task paused outside of Terraform shows as drift.
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	readResp.State.GetAttribute(ctx, path.Root("status"), &status)
+
+	if readResp.Diagnostics.HasError() || status.ValueString() != "inactive" {
+		t.Errorf("expected the read to reflect the paused task, got %v %s", readResp.Diagnostics, status)
+	}
+}
+
+func TestTaskModifyPlanScheduleTransitions(t *testing.T) {
+	ctx := context.Background()
+
+	// The script of taskModel runs every hour, without cron or offset.
+	for _, test := range []struct {
+		name                string
+		state, config       [3]string
+		every, cron, offset string
+	}{
+		{name: "every to cron", state: [3]string{"2h", "", ""}, config: [3]string{"", "0 * * * *", ""}, every: "null", cron: "0 * * * *", offset: "null"},
+		{name: "cron to every", state: [3]string{"", "0 * * * *", ""}, config: [3]string{"30m", "", ""}, every: "30m", cron: "null", offset: "null"},
+		{name: "every override removed", state: [3]string{"2h", "", ""}, every: "unknown", cron: "null", offset: "null"},
+		{name: "cron override removed", state: [3]string{"", "0 * * * *", ""}, every: "unknown", cron: "unknown", offset: "null"},
+		{name: "offset override removed", state: [3]string{"1h", "", "5m"}, every: "1h", cron: "null", offset: "unknown"},
+		{name: "schedule of the script", state: [3]string{"60m", "", ""}, every: "60m", cron: "null", offset: "null"},
+		{name: "offset kept", state: [3]string{"1h", "", "5m"}, config: [3]string{"", "", "300s"}, every: "1h", cron: "null", offset: "300s"},
+	} {
+		value := func(value string) types.String {
+			return stringValueOrNull(&value)
+		}
+
+		state := taskModel()
+		state.Id, state.Status, state.Name = types.StringValue("0000000000000001"), types.StringValue("active"), types.StringValue("downsample")
+		state.CreatedAt, state.UpdatedAt = types.StringNull(), types.StringNull()
+		state.EffectiveLabels = types.SetNull(types.ObjectType{AttrTypes: effectiveLabelAttrTypes})
+		state.Every, state.Cron, state.Offset = fluxDurationValue{StringValue: value(test.state[0])}, value(test.state[1]), fluxDurationValue{StringValue: value(test.state[2])}
+		prior := taskPlanFor(t, state)
+
+		// Terraform proposes the prior values of the attributes removed from
+		// the configuration.
+		configured := state
+		configured.Every, configured.Cron, configured.Offset = fluxDurationValue{StringValue: value(test.config[0])}, value(test.config[1]), fluxDurationValue{StringValue: value(test.config[2])}
+		proposed := configured
+
+		for _, pair := range [][2]*types.String{
+			{&proposed.Every.StringValue, &state.Every.StringValue},
+			{&proposed.Cron, &state.Cron},
+			{&proposed.Offset.StringValue, &state.Offset.StringValue},
+		} {
+			if pair[0].IsNull() {
+				*pair[0] = *pair[1]
+			}
+		}
+
+		plan := taskPlanFor(t, proposed)
+		resp := resource.ModifyPlanResponse{Plan: plan}
+
+		(&taskResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: taskConfigFor(t, configured),
+			Plan:   plan,
+			State:  tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+		}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", test.name, resp.Diagnostics)
+		}
+
+		var planned taskResourceModel
+		resp.Plan.Get(ctx, &planned)
+
+		for _, check := range []struct {
+			attribute string
+			got       types.String
+			expected  string
+		}{
+			{"every", planned.Every.StringValue, test.every},
+			{"cron", planned.Cron, test.cron},
+			{"offset", planned.Offset.StringValue, test.offset},
+		} {
+			got := check.got.ValueString()
+
+			switch {
+			case check.got.IsUnknown():
+				got = "unknown"
+			case check.got.IsNull():
+				got = "null"
+			}
+
+			if got != check.expected {
+				t.Errorf("%s: expected %s to be planned %s, got %s", test.name, check.attribute, check.expected, got)
+			}
+		}
+	}
+}
+
+func TestTaskUpdateRevertsToScriptSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	var methods []string
+
+	var patch domain.TaskUpdateRequest
+
+	data := newProviderData(newTaskServer(t, &methods, &patch).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &taskResource{providerData: data}
+
+	model := taskModel()
+	model.Every = fluxDurationValue{StringValue: types.StringValue("2h")}
+	plan := taskPlanFor(t, model)
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	var created taskResourceModel
+	createResp.State.Get(ctx, &created)
+
+	if createResp.Diagnostics.HasError() || created.Every.ValueString() != "2h" {
+		t.Fatalf("expected the every override to be applied, got %v %s", createResp.Diagnostics, created.Every)
+	}
+
+	reverted := created
+	reverted.Every = fluxDurationValue{StringValue: types.StringUnknown()}
+	updateResp := resource.UpdateResponse{State: createResp.State}
+
+	r.Update(ctx, resource.UpdateRequest{Plan: taskPlanFor(t, reverted), State: createResp.State}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if patch.Flux == nil || *patch.Flux != model.Flux.ValueString() || patch.Every != nil || patch.Cron != nil {
+		t.Errorf("expected the script to be sent again without the override, got %+v", patch)
+	}
+
+	var every fluxDurationValue
+	updateResp.State.GetAttribute(ctx, path.Root("every"), &every)
+
+	if every.ValueString() != "1h" {
+		t.Errorf("expected the schedule of the script, got %s", every)
+	}
+}
+
+func TestTaskValidateConfigOffset(t *testing.T) {
+	ctx := context.Background()
+
+	model := taskModel()
+	model.Every, model.Cron = fluxDurationValue{StringValue: types.StringNull()}, types.StringNull()
+	model.Offset = fluxDurationValue{StringValue: types.StringValue("90 minutes")}
+	plan := taskPlanFor(t, model)
+	resp := resource.ValidateConfigResponse{}
+
+	(&taskResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+	errors := resp.Diagnostics.Errors()
+
+	if len(errors) != 1 || !strings.Contains(errors[0].Detail(), "ns, us, ms, s, m, h, d, w, mo or y") {
+		t.Fatalf("expected an error with the accepted syntax, got %v", resp.Diagnostics)
+	}
+
+	if withPath, ok := errors[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("offset")) {
+		t.Errorf("expected an error on offset, got %v", errors[0])
+	}
+}
+
+func TestTaskModifyPlanDefaultLabels(t *testing.T) {
+	ctx := context.Background()
+
+	id, name := "0000000000000040", "managed-by:terraform"
+	effective, _ := flattenLabels(ctx, &domain.Labels{{Id: &id, Name: &name}})
+
+	state := taskModel()
+	state.Id = types.StringValue("0000000000000001")
+	state.EffectiveLabels = effective
+	prior := taskPlanFor(t, state)
+
+	for _, test := range []struct {
+		defaults []string
+		unknown  bool
+	}{
+		{defaults: []string{name}, unknown: false},
+		{defaults: []string{name, "team:platform"}, unknown: true},
+	} {
+		r := &taskResource{providerData: &providerData{defaultLabels: test.defaults}}
+		resp := resource.ModifyPlanResponse{Plan: prior}
+
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: taskConfigFor(t, state), Plan: prior, State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var planned types.Set
+		resp.Plan.GetAttribute(ctx, path.Root("effective_labels"), &planned)
+
+		if planned.IsUnknown() != test.unknown {
+			t.Errorf("%v: expected effective_labels unknown %t, got %s", test.defaults, test.unknown, planned)
+		}
+	}
+}
+
+func TestTaskToModelLastRun(t *testing.T) {
+	ctx := context.Background()
+
+	failed, message := domain.TaskLastRunStatusFailed, "error calling function \"to\": bucket not found"
+
+	for _, test := range []struct {
+		task           domain.Task
+		expectedStatus types.String
+		expectedError  types.String
+	}{
+		{task: domain.Task{}, expectedStatus: types.StringNull(), expectedError: types.StringNull()},
+		{task: domain.Task{LastRunStatus: &failed, LastRunError: &message}, expectedStatus: types.StringValue("failed"), expectedError: types.StringValue(message)},
+	} {
+		var model taskResourceModel
+
+		if diags := taskToModel(ctx, &test.task, &model); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if !model.LastRunStatus.Equal(test.expectedStatus) || !model.LastRunError.Equal(test.expectedError) {
+			t.Errorf("expected last run %s %s, got %s %s", test.expectedStatus, test.expectedError, model.LastRunStatus, model.LastRunError)
+		}
+	}
+}
+
+func TestTaskReadExactMatch(t *testing.T) {
+	ctx := context.Background()
+
+	var methods []string
+
+	data := newProviderData(newTaskServer(t, &methods, &domain.TaskUpdateRequest{}).URL, "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	r := &taskResource{providerData: data}
+
+	plan := taskPlanFor(t, taskModel())
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created taskResourceModel
+	createResp.State.Get(ctx, &created)
+
+	// The configuration was reformatted since the task was created.
+	reformatted := created
+	reformatted.Flux = types.StringValue("option task = {name: \"downsample\", every: 1h}\nfrom(bucket: \"raw\")\n  |> range(start: -task.every)\n")
+
+	for _, exact := range []bool{false, true} {
+		reformatted.ExactMatch = types.BoolValue(exact)
+		state := taskPlanFor(t, reformatted)
+		resp := resource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+
+		r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var flux types.String
+		resp.State.GetAttribute(ctx, path.Root("flux"), &flux)
+
+		expected := reformatted.Flux
+
+		if exact {
+			expected = created.Flux
+		}
+
+		if !flux.Equal(expected) {
+			t.Errorf("exact_match %t: expected the script %s, got %s", exact, expected, flux)
+		}
+	}
+}
+
+// testAccTaskConfig returns a task named name, writing its bucket into itself
+// after filtering on measurement.
+func testAccTaskConfig(name string, measurement string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "influxdbv2_bucket" "test" {
+  name   = %[1]q
+  org_id = data.influxdbv2_organization.test.id
+}
+
+resource "influxdbv2_task" "test" {
+  org_id = data.influxdbv2_organization.test.id
+  flux   = <<-EOT
+    option task = {name: %[1]q, every: 1h}
+
+    from(bucket: %[1]q)
+      |> range(start: -1h)
+      |> filter(fn: (r) => r._measurement == %[2]q)
+      |> to(bucket: %[1]q)
+  EOT
+}
+`, name, measurement)
+}
+
+// TestAccTaskResource creates a task, changes its script and checks the
+// change is applied in place.
+func TestAccTaskResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-task")
+
+	var id string
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: testAccTaskConfig(name, "cpu"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("influxdbv2_task.test", "name", name),
+					resourcetest.TestCheckResourceAttr("influxdbv2_task.test", "every", "1h"),
+					resourcetest.TestCheckResourceAttr("influxdbv2_task.test", "status", "active"),
+					resourcetest.TestCheckResourceAttrWith("influxdbv2_task.test", "id", func(value string) error {
+						id = value
+
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccTaskConfig(name, "mem"),
+				ConfigPlanChecks: resourcetest.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("influxdbv2_task.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttrWith("influxdbv2_task.test", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected task %s to be updated in place, got task %s", id, value)
+						}
+
+						return nil
+					}),
+					resourcetest.TestCheckResourceAttrWith("influxdbv2_task.test", "flux", func(value string) error {
+						if !strings.Contains(value, `"mem"`) {
+							return fmt.Errorf("expected the updated script, got %s", value)
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            "influxdbv2_task.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_first_run", "first_run_timeout", "validate_on_plan"},
+			},
+		},
+	})
+}
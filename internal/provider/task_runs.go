@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+const (
+	// defaultFirstRunTimeout bounds the wait for the first run of a task.
+	defaultFirstRunTimeout = "5m"
+
+	// taskRunLogExcerptLines is how many of the last log messages of a failed
+	// run are reported.
+	taskRunLogExcerptLines = 5
+)
+
+// taskRunPollInterval is the delay between two checks of a run status.
+var taskRunPollInterval = time.Second
+
+// validateFirstRunTimeout checks that first_run_timeout is a positive
+// duration.
+func validateFirstRunTimeout(timeout types.String, diags *diag.Diagnostics) {
+	if timeout.IsUnknown() || timeout.IsNull() {
+		return
+	}
+
+	if value, err := time.ParseDuration(timeout.ValueString()); err != nil || value <= 0 {
+		diags.AddAttributeError(
+			path.Root("first_run_timeout"),
+			"Invalid duration",
+			fmt.Sprintf("first_run_timeout must be a positive duration such as 5m, got %q.", timeout.ValueString()),
+		)
+	}
+}
+
+// waitForFirstRun starts a run of the task with taskID and waits until it
+// completes. A failed or canceled run is returned as an error with the end of
+// its log.
+func waitForFirstRun(ctx context.Context, client influxdb2.Client, taskID string, timeout time.Duration) error {
+	tasksAPI := client.TasksAPI()
+
+	run, err := tasksAPI.RunManuallyWithID(ctx, taskID)
+
+	if err != nil {
+		return fmt.Errorf("could not start the first run: %w", err)
+	}
+
+	runID := stringValueOrNull(run.Id).ValueString()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		status := domain.RunStatusScheduled
+
+		if run.Status != nil {
+			status = *run.Status
+		}
+
+		switch status {
+		case domain.RunStatusSuccess:
+			return nil
+		case domain.RunStatusFailed, domain.RunStatusCanceled:
+			return fmt.Errorf("the first run %s ended with status %s%s", runID, status, taskRunLogExcerpt(ctx, tasksAPI, taskID, run))
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for run %s of task %s, status %s", runID, taskID, status))
+
+		select {
+		case <-ctx.Done():
+			return firstRunContextError(ctx, runID, timeout)
+		case <-time.After(taskRunPollInterval):
+		}
+
+		runs, err := tasksAPI.FindRunsWithID(ctx, taskID, nil)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return firstRunContextError(ctx, runID, timeout)
+			}
+
+			return fmt.Errorf("could not read the runs of the task: %w", err)
+		}
+
+		for i := range runs {
+			if stringValueOrNull(runs[i].Id).ValueString() == runID {
+				run = &runs[i]
+			}
+		}
+	}
+}
+
+// firstRunContextError reports why waiting for a run stopped once ctx is
+// done.
+func firstRunContextError(ctx context.Context, runID string, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("the first run %s did not complete within %s", runID, timeout)
+	}
+
+	return ctx.Err()
+}
+
+// taskRunLogExcerpt formats the last messages of the log of run, read from
+// the run itself or fetched when the run does not include them.
+func taskRunLogExcerpt(ctx context.Context, tasksAPI api.TasksAPI, taskID string, run *domain.Run) string {
+	var events []domain.LogEvent
+
+	if run.Log != nil {
+		events = *run.Log
+	}
+
+	if len(events) == 0 && run.Id != nil {
+		fetched, err := tasksAPI.FindRunLogsWithID(ctx, taskID, *run.Id)
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Could not read the log of run %s: %s", *run.Id, err))
+		}
+
+		events = fetched
+	}
+
+	var messages []string
+
+	for _, event := range events[max(0, len(events)-taskRunLogExcerptLines):] {
+		messages = append(messages, stringValueOrNull(event.Message).ValueString())
+	}
+
+	if len(messages) == 0 {
+		return ""
+	}
+
+	return ":\n" + strings.Join(messages, "\n")
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTaskRunServer starts runs of task 0000000000000001 and reports them
+// with the given statuses, one per poll, the last one repeated.
+func newTaskRunServer(t *testing.T, statuses ...string) *httptest.Server {
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/tasks/0000000000000001/runs":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"0000000000000002","taskID":"0000000000000001","status":"scheduled"}`))
+		case r.URL.Path == "/api/v2/tasks/0000000000000001/runs":
+			status := statuses[min(polls, len(statuses)-1)]
+			polls++
+
+			_, _ = fmt.Fprintf(w, `{"runs":[{"id":"0000000000000003","status":"failed"},{"id":"0000000000000002","taskID":"0000000000000001","status":%q}]}`, status)
+		case r.URL.Path == "/api/v2/tasks/0000000000000001/runs/0000000000000002/logs":
+			_, _ = w.Write([]byte(`{"events":[{"message":"Started task from script"},{"message":"error exhausting result iterator: bucket \"raw\" not found"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not found","message":"not found"}`))
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWaitForFirstRun(t *testing.T) {
+	previous := taskRunPollInterval
+	taskRunPollInterval = time.Millisecond
+	t.Cleanup(func() { taskRunPollInterval = previous })
+
+	tests := []struct {
+		name     string
+		statuses []string
+		timeout  time.Duration
+		expected string
+	}{
+		{name: "success", statuses: []string{"started", "success"}, timeout: time.Minute},
+		{name: "failed", statuses: []string{"started", "failed"}, timeout: time.Minute, expected: `ended with status failed:
+Started task from script
+error exhausting result iterator: bucket "raw" not found`},
+		{name: "timeout", statuses: []string{"started"}, timeout: 20 * time.Millisecond, expected: "did not complete within 20ms"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTaskRunServer(t, test.statuses...)
+
+			client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+			defer client.Close()
+
+			err := waitForFirstRun(context.Background(), client, "0000000000000001", test.timeout)
+
+			if test.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestWaitForFirstRunCancelled(t *testing.T) {
+	server := newTaskRunServer(t, "started")
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := waitForFirstRun(ctx, client, "0000000000000001", time.Minute); err == nil {
+		t.Error("expected an error once the context is cancelled")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &telegrafPluginsDataSource{}
+	_ datasource.DataSourceWithConfigure = &telegrafPluginsDataSource{}
+)
+
+// telegrafPluginTypes lists the plugin types the server may return.
+var telegrafPluginTypes = []string{"input", "output", "aggregator", "processor"}
+
+func TelegrafPluginsDataSource() datasource.DataSource {
+	return &telegrafPluginsDataSource{}
+}
+
+type telegrafPluginsDataSource struct {
+	client influxdb2.Client
+}
+
+// telegrafPluginsDataSourceModel describes the data source data model.
+type telegrafPluginsDataSourceModel struct {
+	Id      types.String          `tfsdk:"id"`
+	Type    types.String          `tfsdk:"type"`
+	Plugins []telegrafPluginModel `tfsdk:"plugins"`
+}
+
+type telegrafPluginModel struct {
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+}
+
+// telegrafPlugin is one plugin of the /telegraf/plugins response.
+type telegrafPlugin struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Config      string `json:"config"`
+}
+
+func (d *telegrafPluginsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_telegraf_plugins"
+}
+
+func (d *telegrafPluginsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Telegraf plugins known to the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Plugin type the list was read for, `all` without a filter",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return plugins of this type, `input`, `output`, `aggregator` or `processor`",
+				Optional:            true,
+			},
+			"plugins": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching plugins",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Plugin name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Plugin type",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Plugin description",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *telegrafPluginsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// fetchTelegrafPlugins returns the Telegraf plugins known to the server,
+// only those of pluginType when it is not empty.
+func fetchTelegrafPlugins(ctx context.Context, client influxdb2.Client, pluginType string) ([]telegrafPlugin, error) {
+	query := url.Values{}
+
+	if pluginType != "" {
+		query.Set("type", pluginType)
+	}
+
+	payload, err := influxrest.Do(ctx, client, influxrest.Request{
+		Method: http.MethodGet,
+		Path:   "telegraf/plugins",
+		Query:  query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Plugins []telegrafPlugin `json:"plugins"`
+	}
+
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, err
+	}
+
+	// Older servers ignore the type parameter.
+	plugins := []telegrafPlugin{}
+
+	for _, plugin := range response.Plugins {
+		if pluginType == "" || plugin.Type == pluginType {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	return plugins, nil
+}
+
+func (d *telegrafPluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state telegrafPluginsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Type.IsNull() {
+		if err := validateOneOf("type", state.Type.ValueString(), telegrafPluginTypes); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("type"), "Invalid plugin type", err.Error())
+
+			return
+		}
+	}
+
+	plugins, err := fetchTelegrafPlugins(ctx, d.client, state.Type.ValueString())
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error reading Telegraf plugins",
+			fmt.Sprintf("Could not read Telegraf plugins : %s", err),
+		)
+
+		return
+	}
+
+	state.Id = types.StringValue("all")
+
+	if !state.Type.IsNull() {
+		state.Id = state.Type
+	}
+
+	state.Plugins = []telegrafPluginModel{}
+
+	for _, plugin := range plugins {
+		state.Plugins = append(state.Plugins, telegrafPluginModel{
+			Name:        types.StringValue(plugin.Name),
+			Type:        types.StringValue(plugin.Type),
+			Description: types.StringValue(plugin.Description),
+		})
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
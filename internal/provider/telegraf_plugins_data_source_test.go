@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchTelegrafPlugins(t *testing.T) {
+	var requestedType string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/telegraf/plugins" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		requestedType = r.URL.Query().Get("type")
+
+		// The type parameter is ignored, as by older servers.
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "1.28.0", "os": "unix", "plugins": [
+			{"type": "input", "name": "cpu", "description": "Read metrics about cpu usage", "config": "[[inputs.cpu]]"},
+			{"type": "output", "name": "influxdb_v2", "description": "Configuration for sending metrics to InfluxDB 2.0", "config": "[[outputs.influxdb_v2]]"},
+			{"type": "input", "name": "mem", "description": "Read metrics about memory usage", "config": "[[inputs.mem]]"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	all, err := fetchTelegrafPlugins(context.Background(), client, "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(all) != 3 || requestedType != "" {
+		t.Errorf("expected every plugin without a type parameter, got %+v with type %q", all, requestedType)
+	}
+
+	inputs, err := fetchTelegrafPlugins(context.Background(), client, "input")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(inputs) != 2 || inputs[0].Name != "cpu" || inputs[1].Name != "mem" || requestedType != "input" {
+		t.Errorf("expected the input plugins, got %+v with type %q", inputs, requestedType)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &templateExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &templateExportDataSource{}
+)
+
+func TemplateExportDataSource() datasource.DataSource {
+	return &templateExportDataSource{}
+}
+
+type templateExportDataSource struct {
+	client influxdb2.Client
+}
+
+// templateExportDataSourceModel describes the data source data model.
+type templateExportDataSourceModel struct {
+	Id            types.String                  `tfsdk:"id"`
+	OrgID         types.String                  `tfsdk:"org_id"`
+	ResourceKinds []types.String                `tfsdk:"resource_kinds"`
+	LabelNames    []types.String                `tfsdk:"label_names"`
+	Resources     []templateExportResourceModel `tfsdk:"resources"`
+	YAML          types.String                  `tfsdk:"yaml"`
+	JSON          types.String                  `tfsdk:"json"`
+}
+
+type templateExportResourceModel struct {
+	Kind types.String `tfsdk:"kind"`
+	Id   types.String `tfsdk:"id"`
+}
+
+func (d *templateExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template_export"
+}
+
+func (d *templateExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports resources of an organization as an InfluxDB template",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization id the template was exported from",
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id to export",
+				Required:            true,
+			},
+			"resource_kinds": schema.ListAttribute{
+				MarkdownDescription: "Only export resources of these kinds, for example `Bucket` or `Dashboard`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"label_names": schema.ListAttribute{
+				MarkdownDescription: "Only export resources with one of these labels",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"resources": schema.ListNestedAttribute{
+				MarkdownDescription: "Specific resources to export in addition to the organization filters",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Resource kind, for example `Bucket`",
+							Required:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Resource id",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"yaml": schema.StringAttribute{
+				MarkdownDescription: "Exported template as YAML",
+				Computed:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "Exported template as JSON, with resources sorted by kind and name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *templateExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// templateExportRequest builds the export request body from the model.
+func templateExportRequest(state templateExportDataSourceModel) map[string]interface{} {
+	filters := map[string]interface{}{}
+
+	if len(state.ResourceKinds) > 0 {
+		var kinds []string
+
+		for _, kind := range state.ResourceKinds {
+			kinds = append(kinds, kind.ValueString())
+		}
+
+		filters["byResourceKind"] = kinds
+	}
+
+	if len(state.LabelNames) > 0 {
+		var labels []string
+
+		for _, label := range state.LabelNames {
+			labels = append(labels, label.ValueString())
+		}
+
+		filters["byLabel"] = labels
+	}
+
+	org := map[string]interface{}{
+		"orgID": state.OrgID.ValueString(),
+	}
+
+	if len(filters) > 0 {
+		org["resourceFilters"] = filters
+	}
+
+	body := map[string]interface{}{
+		"orgIDs": []interface{}{org},
+	}
+
+	if len(state.Resources) > 0 {
+		var resources []map[string]string
+
+		for _, resource := range state.Resources {
+			resources = append(resources, map[string]string{
+				"kind": resource.Kind.ValueString(),
+				"id":   resource.Id.ValueString(),
+			})
+		}
+
+		body["resources"] = resources
+	}
+
+	return body
+}
+
+// normalizeTemplateJSON sorts template resources by kind and name and indents
+// the result so exports can be diffed between runs.
+func normalizeTemplateJSON(payload []byte) (string, error) {
+	var resources []map[string]interface{}
+
+	if err := json.Unmarshal(payload, &resources); err != nil {
+		return "", err
+	}
+
+	sortKey := func(resource map[string]interface{}) string {
+		name := ""
+
+		if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+			name = fmt.Sprint(metadata["name"])
+		}
+
+		return fmt.Sprintf("%v/%s", resource["kind"], name)
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return sortKey(resources[i]) < sortKey(resources[j])
+	})
+
+	normalized, err := json.MarshalIndent(resources, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+func (d *templateExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state templateExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := templateExportRequest(state)
+
+	jsonPayload, err := influxrest.Do(ctx, d.client, influxrest.Request{
+		Method: http.MethodPost,
+		Path:   "templates/export",
+		Accept: "application/json",
+		Body:   body,
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error exporting template",
+			fmt.Sprintf("Could not export template for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	yamlPayload, err := influxrest.Do(ctx, d.client, influxrest.Request{
+		Method: http.MethodPost,
+		Path:   "templates/export",
+		Accept: "application/x-yaml",
+		Body:   body,
+	})
+
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics,
+			"Error exporting template",
+			fmt.Sprintf("Could not export template for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	normalized, err := normalizeTemplateJSON(jsonPayload)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting template",
+			fmt.Sprintf("Could not decode exported template for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	state.Id = state.OrgID
+	state.JSON = types.StringValue(normalized)
+	state.YAML = types.StringValue(string(yamlPayload))
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
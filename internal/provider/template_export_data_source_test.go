@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTemplateExportRequest(t *testing.T) {
+	body := templateExportRequest(templateExportDataSourceModel{
+		OrgID:         types.StringValue("0000000000000001"),
+		ResourceKinds: []types.String{types.StringValue("Bucket")},
+		LabelNames:    []types.String{types.StringValue("managed-by:terraform")},
+		Resources: []templateExportResourceModel{
+			{Kind: types.StringValue("Dashboard"), Id: types.StringValue("0000000000000002")},
+		},
+	})
+
+	payload, err := json.Marshal(body)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"orgIDs":[{"orgID":"0000000000000001","resourceFilters":{"byLabel":["managed-by:terraform"],"byResourceKind":["Bucket"]}}],"resources":[{"id":"0000000000000002","kind":"Dashboard"}]}`
+
+	if string(payload) != expected {
+		t.Errorf("unexpected request body:\n%s\nexpected:\n%s", payload, expected)
+	}
+}
+
+func TestNormalizeTemplateJSON(t *testing.T) {
+	first, err := normalizeTemplateJSON([]byte(`[
+		{"kind":"Dashboard","metadata":{"name":"b"},"spec":{}},
+		{"kind":"Bucket","metadata":{"name":"z"},"spec":{"name":"z"}},
+		{"kind":"Bucket","metadata":{"name":"a"},"spec":{"name":"a"}}
+	]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second, err := normalizeTemplateJSON([]byte(`[
+		{"kind":"Bucket","metadata":{"name":"a"},"spec":{"name":"a"}},
+		{"spec":{},"metadata":{"name":"b"},"kind":"Dashboard"},
+		{"kind":"Bucket","metadata":{"name":"z"},"spec":{"name":"z"}}
+	]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if first != second {
+		t.Errorf("expected equivalent exports to normalize identically:\n%s\n%s", first, second)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// tlsVersions maps the accepted tls_min_version values to crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsVersionNames returns the accepted tls_min_version values, sorted.
+func tlsVersionNames() []string {
+	names := make([]string, 0, len(tlsVersions))
+
+	for name := range tlsVersions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// cipherSuiteID returns the id of the secure TLS 1.2 cipher suite called name,
+// using the Go names such as TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256.
+func cipherSuiteID(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name != name {
+			continue
+		}
+
+		for _, version := range suite.SupportedVersions {
+			if version == tls.VersionTLS12 {
+				return suite.ID, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// newTLSTransport returns a copy of the default transport negotiating at least
+// minVersion and, for TLS 1.2, only cipherSuites when set. Handshake failures
+// are reported with the configured minimum version.
+func newTLSTransport(minVersion string, cipherSuites []uint16) http.RoundTripper {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:   tlsVersions[minVersion],
+		CipherSuites: cipherSuites,
+	}
+
+	if minVersion == "" {
+		transport.TLSClientConfig.MinVersion = tls.VersionTLS12
+		minVersion = "1.2"
+	}
+
+	return &tlsHandshakeTransport{next: transport, minVersion: minVersion}
+}
+
+// tlsHandshakeTransport explains TLS errors in terms of the provider settings.
+type tlsHandshakeTransport struct {
+	next       http.RoundTripper
+	minVersion string
+}
+
+func (t *tlsHandshakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil && isTLSHandshakeError(err) {
+		return resp, fmt.Errorf("TLS handshake with %s failed, the server may not support tls_min_version = %q or the configured cipher suites: %w", req.URL.Host, t.minVersion, err)
+	}
+
+	return resp, err
+}
+
+func isTLSHandshakeError(err error) bool {
+	var alert tls.AlertError
+
+	return errors.As(err, &alert) || strings.Contains(err.Error(), "tls: ")
+}
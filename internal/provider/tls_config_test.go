@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCipherSuiteID(t *testing.T) {
+	if id, ok := cipherSuiteID("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"); !ok || id != tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 {
+		t.Errorf("expected the TLS 1.2 suite to be accepted, got %d %t", id, ok)
+	}
+
+	for _, name := range []string{"TLS_AES_128_GCM_SHA256", "TLS_RSA_WITH_RC4_128_SHA", "unknown"} {
+		if _, ok := cipherSuiteID(name); ok {
+			t.Errorf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestTLSTransportReportsMinVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	client := &http.Client{Transport: newTLSTransport("1.3", nil)}
+
+	_, err := client.Get(server.URL)
+
+	if err == nil || !strings.Contains(err.Error(), `tls_min_version = "1.3"`) {
+		t.Errorf("expected a handshake error naming the minimum version, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+var (
+	// tokenCommandTimeout bounds the run of token_command.
+	tokenCommandTimeout = 30 * time.Second
+
+	// tokenCommandSlowThreshold is how long token_command may run before
+	// the provider warns that it slows down every plan and apply.
+	tokenCommandSlowThreshold = 2 * time.Second
+)
+
+// splitCommand splits command into arguments the way a shell does, honoring
+// single quotes, double quotes and backslash escapes, without expanding
+// variables, globs or any other shell syntax.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+
+	inArg, escaped := false, false
+	var quote rune
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped || quote != 0 {
+		return nil, errors.New("unterminated quote or escape")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	return args, nil
+}
+
+// runTokenCommand runs command and returns its trimmed standard output along
+// with how long it ran. The output is a credential and never logged.
+func runTokenCommand(ctx context.Context, command string) (string, time.Duration, error) {
+	args, err := splitCommand(command)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err = cmd.Run()
+	elapsed := time.Since(start)
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", elapsed, fmt.Errorf("the command did not complete within %s", tokenCommandTimeout)
+	}
+
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", elapsed, fmt.Errorf("%w: %s", err, message)
+		}
+
+		return "", elapsed, err
+	}
+
+	token := strings.TrimSpace(stdout.String())
+
+	if token == "" {
+		return "", elapsed, errors.New("the command printed no token")
+	}
+
+	return token, elapsed, nil
+}
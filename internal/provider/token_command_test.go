@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitCommand(t *testing.T) {
+	for command, expected := range map[string][]string{
+		"vault read -field=token secret/influxdb": {"vault", "read", "-field=token", "secret/influxdb"},
+		`  op  read  "op://dev/influx db/token" `: {"op", "read", "op://dev/influx db/token"},
+		`echo '$HOME "quoted"' \$USER a\ b`:       {"echo", `$HOME "quoted"`, "$USER", "a b"},
+		`printf ''`:                               {"printf", ""},
+	} {
+		args, err := splitCommand(command)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", command, err)
+		}
+
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("%s: expected %q, got %q", command, expected, args)
+		}
+	}
+
+	for _, command := range []string{"", "   ", `echo "unterminated`, `echo 'unterminated`, `echo \`} {
+		if _, err := splitCommand(command); err == nil {
+			t.Errorf("%q: expected an error", command)
+		}
+	}
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	token, _, err := runTokenCommand(context.Background(), `printf '  secret\n'`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if token != "secret" {
+		t.Errorf("expected the trimmed token, got %q", token)
+	}
+
+	for command, expected := range map[string]string{
+		`sh -c 'echo permission denied >&2; exit 3'`: "exit status 3: permission denied",
+		`printf ''`:                      "printed no token",
+		"terraform-provider-test-absent": "executable file not found",
+	} {
+		if _, _, err := runTokenCommand(context.Background(), command); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", command, expected, err)
+		}
+	}
+}
+
+func TestRunTokenCommandTimeout(t *testing.T) {
+	previous := tokenCommandTimeout
+	tokenCommandTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { tokenCommandTimeout = previous })
+
+	_, _, err := runTokenCommand(context.Background(), "sleep 5")
+
+	if err == nil || !strings.Contains(err.Error(), "did not complete within") {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+}
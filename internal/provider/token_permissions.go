@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+// managedPermissions maps each resource type of the provider to the write
+// permission it needs.
+var managedPermissions = map[string]domain.ResourceType{
+	"influxdbv2_authorization":                  domain.ResourceTypeAuthorizations,
+	"influxdbv2_bucket":                         domain.ResourceTypeBuckets,
+	"influxdbv2_bucket_member":                  domain.ResourceTypeBuckets,
+	"influxdbv2_check_custom":                   domain.ResourceTypeChecks,
+	"influxdbv2_downsampling_task":              domain.ResourceTypeTasks,
+	"influxdbv2_notification_endpoint_telegram": domain.ResourceTypeNotificationEndpoints,
+	"influxdbv2_organization":                   domain.ResourceTypeOrgs,
+	"influxdbv2_organization_member":            domain.ResourceTypeOrgs,
+	"influxdbv2_organization_members":           domain.ResourceTypeOrgs,
+	"influxdbv2_organization_owner":             domain.ResourceTypeOrgs,
+	"influxdbv2_task":                           domain.ResourceTypeTasks,
+}
+
+// missingTokenPermissions inspects the authorization of token through /me and
+// the authorizations of its user, and returns the permission types of
+// managedPermissions the token cannot write. Permissions scoped to an
+// organization or a single resource count as granted since the configuration
+// may only use those.
+func missingTokenPermissions(ctx context.Context, client influxdb2.Client, token string) (map[domain.ResourceType]bool, error) {
+	me, err := client.UsersAPI().Me(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if me.Id == nil {
+		return nil, errors.New("the server did not return the id of the token user")
+	}
+
+	authorizations, err := client.AuthorizationsAPI().FindAuthorizationsByUserID(ctx, *me.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []domain.Permission
+
+	found := false
+
+	for _, authorization := range *authorizations {
+		if authorization.Token != nil && *authorization.Token == token && authorization.Permissions != nil {
+			permissions = *authorization.Permissions
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return nil, errors.New("the token is not listed among the authorizations of its user")
+	}
+
+	missing := map[domain.ResourceType]bool{}
+
+	for _, resourceType := range managedPermissions {
+		granted := false
+
+		for _, permission := range permissions {
+			if permission.Action == domain.PermissionActionWrite && permission.Resource.Type == resourceType {
+				granted = true
+
+				break
+			}
+		}
+
+		if !granted {
+			missing[resourceType] = true
+		}
+	}
+
+	return missing, nil
+}
+
+// warnMissingPermission emits, the first time a resource of typeName that the
+// provider token cannot manage is planned, a single warning listing every
+// resource type of the provider the token cannot manage. Terraform plans
+// resources one at a time, so the warning cannot be limited to the rest of the
+// configuration; it is not emitted at all when no resource of the
+// configuration is affected.
+func (d *providerData) warnMissingPermission(typeName string, diags *diag.Diagnostics) {
+	if d == nil {
+		return
+	}
+
+	resourceType, ok := managedPermissions[typeName]
+
+	if !ok || !d.missingPermissions[resourceType] {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.permissionWarned {
+		return
+	}
+
+	d.permissionWarned = true
+
+	typeNames := map[domain.ResourceType][]string{}
+
+	for name, resourceType := range managedPermissions {
+		if d.missingPermissions[resourceType] {
+			typeNames[resourceType] = append(typeNames[resourceType], name)
+		}
+	}
+
+	lines := make([]string, 0, len(typeNames))
+
+	for resourceType, names := range typeNames {
+		sort.Strings(names)
+
+		lines = append(lines, fmt.Sprintf("The provider token lacks write:%s; %s resources will fail.", resourceType, strings.Join(names, ", ")))
+	}
+
+	sort.Strings(lines)
+
+	diags.AddWarning(
+		"Insufficient token permissions",
+		strings.Join(lines, "\n")+"\n\nSet skip_permission_check = true to disable this check.",
+	)
+}
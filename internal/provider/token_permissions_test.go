@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestMissingTokenPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v2/me":
+			_, _ = w.Write([]byte(`{"id":"0000000000000100","name":"terraform"}`))
+		case "/api/v2/authorizations":
+			if r.URL.Query().Get("userID") != "0000000000000100" {
+				t.Errorf("expected the user filter, got %q", r.URL.RawQuery)
+			}
+
+			_, _ = w.Write([]byte(`{"authorizations":[
+				{"id":"1","token":"other","permissions":[{"action":"write","resource":{"type":"orgs"}}]},
+				{"id":"2","token":"token","permissions":[
+					{"action":"read","resource":{"type":"orgs"}},
+					{"action":"write","resource":{"type":"buckets","orgID":"0000000000000001"}}
+				]}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	missing, err := missingTokenPermissions(context.Background(), client, "token")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !missing[domain.ResourceTypeOrgs] || missing[domain.ResourceTypeBuckets] {
+		t.Errorf("unexpected missing permissions %v", missing)
+	}
+
+	if _, err := missingTokenPermissions(context.Background(), client, "unknown"); err == nil {
+		t.Error("expected an error when the token authorization cannot be found")
+	}
+}
+
+func TestWarnMissingPermission(t *testing.T) {
+	data := newProviderData("http://localhost:8086", "token", http.DefaultTransport)
+	defer data.client.Close()
+
+	data.missingPermissions = map[domain.ResourceType]bool{domain.ResourceTypeOrgs: true, domain.ResourceTypeTasks: true}
+
+	var diags diag.Diagnostics
+
+	data.warnMissingPermission("influxdbv2_bucket", &diags)
+
+	if diags.WarningsCount() != 0 {
+		t.Errorf("expected no warning for a resource type the token can manage, got %v", diags)
+	}
+
+	for _, typeName := range []string{"influxdbv2_organization", "influxdbv2_task", "influxdbv2_organization"} {
+		data.warnMissingPermission(typeName, &diags)
+	}
+
+	expected := "The provider token lacks write:orgs; influxdbv2_organization, influxdbv2_organization_member, " +
+		"influxdbv2_organization_members, influxdbv2_organization_owner resources will fail.\n" +
+		"The provider token lacks write:tasks; influxdbv2_downsampling_task, influxdbv2_task resources will fail.\n\n" +
+		"Set skip_permission_check = true to disable this check."
+
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Detail() != expected {
+		t.Errorf("expected a single warning listing the missing permissions, got %v", diags)
+	}
+
+	(*providerData)(nil).warnMissingPermission("influxdbv2_organization", &diags)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unixSocketHost is the host of request URLs sent over a unix domain socket.
+// The socket transport ignores it, but the client needs an http URL.
+const unixSocketHost = "http://unix"
+
+// unixSocketPath returns the socket path of unix:///path hosts.
+func unixSocketPath(host string) (string, bool) {
+	parsed, err := url.Parse(host)
+
+	if err != nil || !strings.EqualFold(parsed.Scheme, "unix") || parsed.Path == "" {
+		return "", false
+	}
+
+	return parsed.Path, true
+}
+
+// newUnixSocketTransport returns a transport connecting every request to the
+// socket at path.
+func newUnixSocketTransport(path string) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+
+		return dialer.DialContext(ctx, "unix", path)
+	}
+
+	return transport
+}
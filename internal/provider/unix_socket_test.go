@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	for host, expected := range map[string]string{
+		"unix:///var/run/influxdb.sock": "/var/run/influxdb.sock",
+		"UNIX:///tmp/influx.sock":       "/tmp/influx.sock",
+		"http://localhost:8086":         "",
+		"unix://":                       "",
+	} {
+		path, ok := unixSocketPath(host)
+
+		if path != expected || ok != (expected != "") {
+			t.Errorf("%s: expected %q, got %q %t", host, expected, path, ok)
+		}
+	}
+}
+
+// newUnixSocketBucketServer serves bucket create, read, update and delete
+// requests from memory on a unix socket and returns the socket path.
+func newUnixSocketBucketServer(t *testing.T) string {
+	socket := filepath.Join(t.TempDir(), "influxdb.sock")
+
+	listener, err := net.Listen("unix", socket)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var mu sync.Mutex
+
+	buckets := map[string]domain.Bucket{}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/buckets/")
+		bucket, found := buckets[id]
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/buckets":
+			_ = json.NewDecoder(r.Body).Decode(&bucket)
+			bucket.Id = &[]string{"0000000000000001"}[0]
+			buckets[*bucket.Id] = bucket
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(bucket)
+		case !found:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not found","message":"bucket not found"}`))
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(bucket)
+		case r.Method == http.MethodPatch:
+			_ = json.NewDecoder(r.Body).Decode(&bucket)
+			buckets[id] = bucket
+
+			_ = json.NewEncoder(w).Encode(bucket)
+		case r.Method == http.MethodDelete:
+			delete(buckets, id)
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func TestUnixSocketBucketCRUD(t *testing.T) {
+	ctx := context.Background()
+	socket := newUnixSocketBucketServer(t)
+
+	data := newProviderData(unixSocketHost, "token", newUnixSocketTransport(socket))
+	defer data.client.Close()
+
+	bucketsAPI := data.client.BucketsAPI()
+	orgID := "0000000000000002"
+
+	created, err := bucketsAPI.CreateBucket(ctx, &domain.Bucket{Name: "bucket", OrgID: &orgID})
+
+	if err != nil {
+		t.Fatalf("unexpected error creating the bucket: %s", err)
+	}
+
+	created.Name = "renamed"
+
+	if _, err := bucketsAPI.UpdateBucket(ctx, created); err != nil {
+		t.Fatalf("unexpected error updating the bucket: %s", err)
+	}
+
+	read, err := bucketsAPI.FindBucketByID(ctx, *created.Id)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading the bucket: %s", err)
+	}
+
+	if read.Name != "renamed" {
+		t.Errorf("expected the bucket to be renamed, got %s", read.Name)
+	}
+
+	if err := bucketsAPI.DeleteBucketWithID(ctx, *created.Id); err != nil {
+		t.Fatalf("unexpected error deleting the bucket: %s", err)
+	}
+
+	if _, err := bucketsAPI.FindBucketByID(ctx, *created.Id); !isNotFound(err) {
+		t.Errorf("expected the bucket to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &usageDataSource{}
+	_ datasource.DataSourceWithConfigure = &usageDataSource{}
+)
+
+func UsageDataSource() datasource.DataSource {
+	return &usageDataSource{}
+}
+
+type usageDataSource struct {
+	client influxdb2.Client
+}
+
+// usageDataSourceModel describes the data source data model.
+type usageDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	OrgID        types.String `tfsdk:"org_id"`
+	Start        types.String `tfsdk:"start"`
+	Stop         types.String `tfsdk:"stop"`
+	WriteBytes   types.Int64  `tfsdk:"write_bytes"`
+	QueryCount   types.Int64  `tfsdk:"query_count"`
+	StorageBytes types.Int64  `tfsdk:"storage_bytes"`
+}
+
+// usageTotals holds the totals computed from a usage CSV document.
+type usageTotals struct {
+	writeBytes   int64
+	queryCount   int64
+	storageBytes int64
+}
+
+func (d *usageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage"
+}
+
+func (d *usageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Usage of an InfluxDB Cloud organization over a time range. Requires InfluxDB Cloud.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization id the usage was read for",
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization id",
+				Required:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "Start of the time range, as an RFC3339 timestamp",
+				Required:            true,
+			},
+			"stop": schema.StringAttribute{
+				MarkdownDescription: "End of the time range, as an RFC3339 timestamp. Defaults to now.",
+				Optional:            true,
+			},
+			"write_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Bytes written during the time range",
+				Computed:            true,
+			},
+			"query_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of queries executed during the time range",
+				Computed:            true,
+			},
+			"storage_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Bytes stored at the end of the time range",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *usageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// usageValue converts a numeric CSV value to an int64.
+func usageValue(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// parseUsage sums the write and query usage and keeps the latest storage
+// value per bucket from an annotated CSV usage document.
+func parseUsage(payload []byte) (usageTotals, error) {
+	var totals usageTotals
+
+	type storageSample struct {
+		time  time.Time
+		value int64
+	}
+
+	storage := map[string]storageSample{}
+	result := api.NewQueryTableResult(io.NopCloser(bytes.NewReader(payload)))
+
+	for result.Next() {
+		record := result.Record()
+
+		switch record.Measurement() {
+		case "http_request":
+			if record.Field() == "req_bytes" {
+				totals.writeBytes += usageValue(record.Value())
+			}
+		case "query_count":
+			totals.queryCount += usageValue(record.Value())
+		case "storage_usage_bucket_bytes":
+			bucket := fmt.Sprint(record.ValueByKey("bucket_id"))
+
+			if sample, ok := storage[bucket]; !ok || !record.Time().Before(sample.time) {
+				storage[bucket] = storageSample{time: record.Time(), value: usageValue(record.Value())}
+			}
+		}
+	}
+
+	if result.Err() != nil {
+		return totals, result.Err()
+	}
+
+	for _, sample := range storage {
+		totals.storageBytes += sample.value
+	}
+
+	return totals, nil
+}
+
+func (d *usageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withRequestID(ctx)
+
+	var state usageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+
+	bounds := []struct {
+		name  string
+		value types.String
+	}{
+		{"start", state.Start},
+		{"stop", state.Stop},
+	}
+
+	for _, bound := range bounds {
+		name, value := bound.name, bound.value
+
+		if value.IsNull() {
+			continue
+		}
+
+		if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid timestamp",
+				fmt.Sprintf("%s must be an RFC3339 timestamp, got %q", name, value.ValueString()),
+			)
+
+			continue
+		}
+
+		query.Set(name, value.ValueString())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := influxrest.Do(ctx, d.client, influxrest.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("orgs/%s/usage", url.PathEscape(state.OrgID.ValueString())),
+		Query:  query,
+		Accept: "text/csv",
+	})
+
+	if err != nil {
+		addCloudAPIError(ctx, &resp.Diagnostics, err,
+			"The influxdbv2_usage data source",
+			"Error reading usage",
+			fmt.Sprintf("Could not read usage for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	totals, err := parseUsage(payload)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading usage",
+			fmt.Sprintf("Could not parse usage for organization %s : %s", state.OrgID, err),
+		)
+
+		return
+	}
+
+	state.Id = state.OrgID
+	state.WriteBytes = types.Int64Value(totals.writeBytes)
+	state.QueryCount = types.Int64Value(totals.queryCount)
+	state.StorageBytes = types.Int64Value(totals.storageBytes)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/psenna/terraform-provider-influxdbv2/internal/influxrest"
+)
+
+const testUsageCSV = `#datatype,string,long,dateTime:RFC3339,double,string,string,string
+#group,false,false,false,false,true,true,true
+#default,_result,,,,,,
+,result,table,_time,_value,_field,_measurement,bucket_id
+,,0,2024-01-01T00:00:00Z,100,req_bytes,http_request,b1
+,,0,2024-01-01T01:00:00Z,50,req_bytes,http_request,b1
+,,1,2024-01-01T00:00:00Z,3,req_count,query_count,b1
+,,2,2024-01-01T00:00:00Z,1000,gauge,storage_usage_bucket_bytes,b1
+,,2,2024-01-01T01:00:00Z,1500,gauge,storage_usage_bucket_bytes,b1
+,,3,2024-01-01T01:00:00Z,20,gauge,storage_usage_bucket_bytes,b2
+
+`
+
+func TestParseUsage(t *testing.T) {
+	totals, err := parseUsage([]byte(testUsageCSV))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := usageTotals{writeBytes: 150, queryCount: 3, storageBytes: 1520}
+
+	if totals != expected {
+		t.Errorf("expected %+v, got %+v", expected, totals)
+	}
+}
+
+func TestUsageRequiresCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not found","message":"path not found"}`))
+	}))
+	defer server.Close()
+
+	client := newInfluxClient(server.URL, "token", http.DefaultTransport)
+	defer client.Close()
+
+	ctx := context.Background()
+	_, err := influxrest.Do(ctx, client, influxrest.Request{Method: http.MethodGet, Path: "orgs/0000000000000001/usage"})
+
+	var diags diag.Diagnostics
+	addCloudAPIError(ctx, &diags, err, "The influxdbv2_usage data source", "Error reading usage", "unexpected")
+
+	if diags[0].Summary() != "InfluxDB Cloud required" {
+		t.Errorf("expected the InfluxDB Cloud diagnostic, got %q: %q", diags[0].Summary(), diags[0].Detail())
+	}
+}
@@ -5,8 +5,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/psenna/terraform-provider-influxdbv2/internal/provider"
@@ -32,13 +35,27 @@ var (
 )
 
 func main() {
-	var debug bool
+	var debug, generate bool
+	var output string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&generate, "generate", false, "write import blocks and resource configurations for an existing organization instead of serving, "+
+		"reading INFLUXDB_HOST, INFLUXDB_TOKEN and optionally INFLUXDB_ORG, INFLUXDB_AUTH_SCHEME, INFLUXDB_TLS_MIN_VERSION "+
+		"and INFLUXDB_TLS_CIPHER_SUITES (comma separated) from the environment")
+	flag.StringVar(&output, "out", "generated.tf", "file written by -generate")
 	flag.Parse()
 
+	if generate {
+		if err := generateConfig(output); err != nil {
+			log.Fatal(err.Error())
+		}
+
+		return
+	}
+
+	// In debug mode Serve prints the TF_REATTACH_PROVIDERS value to export
+	// before running terraform, and keeps serving until interrupted.
 	opts := providerserver.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
 		Address: "registry.terraform.io/psenna/influxdbv2",
 		Debug:   debug,
 	}
@@ -49,3 +66,41 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// generateConfig writes the configuration of the organization designated by
+// the environment to output.
+func generateConfig(output string) error {
+	host, token := os.Getenv("INFLUXDB_HOST"), os.Getenv("INFLUXDB_TOKEN")
+
+	if host == "" || token == "" {
+		return errors.New("INFLUXDB_HOST and INFLUXDB_TOKEN must be set")
+	}
+
+	settings := provider.GenerateSettings{
+		Host:          host,
+		Token:         token,
+		Org:           os.Getenv("INFLUXDB_ORG"),
+		AuthScheme:    os.Getenv("INFLUXDB_AUTH_SCHEME"),
+		TLSMinVersion: os.Getenv("INFLUXDB_TLS_MIN_VERSION"),
+	}
+
+	if suites := os.Getenv("INFLUXDB_TLS_CIPHER_SUITES"); suites != "" {
+		for _, suite := range strings.Split(suites, ",") {
+			settings.TLSCipherSuites = append(settings.TLSCipherSuites, strings.TrimSpace(suite))
+		}
+	}
+
+	file, err := os.Create(output)
+
+	if err != nil {
+		return err
+	}
+
+	if err := provider.Generate(context.Background(), settings, file); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}